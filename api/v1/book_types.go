@@ -17,13 +17,58 @@ limitations under the License.
 package v1
 
 import (
+	"hash/fnv"
+	"strconv"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
-// BookSpec defines the desired state of Book
+// ConditionUnmanaged is set on a Book whose namespace is not managed by any BookStore.
+// Such Books are never cleaned up by a store's finalizer.
+const ConditionUnmanaged = "Unmanaged"
+
+// ConditionOutsideWatchScope is set on a copy whose original lives in a namespace the operator
+// does not watch, so the original's referenceCount does not include it.
+const ConditionOutsideWatchScope = "OutsideWatchScope"
+
+// The defaulting webhook sets these labels on every Book so Books can be selected by store, by type,
+// and by original. BookStoreLabel carries the name of the store managing the Book's namespace.
+const (
+	// BookTypeLabel is BookTypeOriginal or BookTypeCopy.
+	BookTypeLabel = "bookstore.example.com/type"
+	// OriginalRefLabel is OriginalRefHash of the original, so an original and all its copies share it.
+	OriginalRefLabel = "bookstore.example.com/original-ref"
+
+	BookTypeOriginal = "original"
+	BookTypeCopy     = "copy"
+)
+
+// InheritsAnnotation lists, comma separated, the spec fields a copy leaves empty and so inherits from
+// its original.
+const InheritsAnnotation = "bookstore.example.com/inherits"
+
+// The defaulting webhook records, from the admission request, who created a Book and who last changed
+// its spec. Both are carried over from the stored Book on every update, so users cannot rewrite them.
+const (
+	CreatedByAnnotation      = "bookstore.example.com/created-by"
+	LastModifiedByAnnotation = "bookstore.example.com/last-modified-by"
+)
+
+// OriginalRefHash returns the OriginalRefLabel value for the original namespace/name. Names can be
+// longer than a label value allows, so the label carries a hash.
+func OriginalRefHash(namespace, name string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(namespace + "/" + name))
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// BookSpec defines the desired state of Book. The rules below repeat the webhook's checks in the
+// API server, so they hold even when the webhook is down or disabled.
+// +kubebuilder:validation:XValidation:rule="has(self.copyOf) || (self.title != '' && self.price != '' && self.genre != '')",message="title, price and genre are required for a Book without copyOf"
+// +kubebuilder:validation:XValidation:rule="!has(self.copyOf) || self.title != '' || self.price != '' || self.genre != ''",message="a Book with copyOf must override at least one of title, price, or genre"
 type BookSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
@@ -38,6 +83,8 @@ type BookSpec struct {
 	CopyOf *CopyOf `json:"copyOf,omitempty"`
 }
 
+// CopyOf points a copy at its original.
+// +kubebuilder:validation:XValidation:rule="self.namespace != '' && self.name != ''",message="copyOf needs both namespace and name"
 type CopyOf struct {
 	Namespace string `json:"namespace"`
 	Name      string `json:"name"`
@@ -67,10 +114,16 @@ type BookStatus struct {
 
 	// +kubebuilder:printcolumn:name="Reference Count",type=integer,JSONPath=`.status.referenceCount`
 	ReferenceCount int `json:"referenceCount"`
+
+	// observedGeneration is the metadata.generation the Book controller last reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:selectablefield:JSONPath=`.spec.copyOf.namespace`
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.status) || oldSelf.status.referenceCount == 0 || has(self.spec.copyOf) == has(oldSelf.spec.copyOf)",message="a Book other Books still count as their original cannot gain or lose spec.copyOf; delete or repoint the copies first",fieldPath=".spec.copyOf"
 
 // Book is the Schema for the books API
 type Book struct {
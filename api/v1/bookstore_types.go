@@ -23,12 +23,70 @@ import (
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// BookStoreLabel is set on a store's Namespace, and by the defaulting webhook on the Books in it, to
+// the name of the BookStore that manages it.
+const BookStoreLabel = "bookstore.example.com/bookstore"
+
+// ShardLabel pins a BookStore, and the Books in its namespace, to a reconciliation shard when the
+// operator runs sharded. Its value is the shard index. Stores without it are assigned by name.
+const ShardLabel = "bookstore.example.com/shard"
+
+// PausedAnnotation suspends reconciliation of a BookStore or Book while it is set to "true". On a
+// BookStore it also pauses every Book in the store's namespace and holds back the finalizer cleanup.
+const PausedAnnotation = "bookstore.example.com/paused"
+
+// ForceDeleteAnnotation lets a BookStore be deleted while it is set to "true", even though other
+// stores still hold copies of its Books.
+const ForceDeleteAnnotation = "bookstore.example.com/force-delete"
+
+// ConditionPaused is set on a BookStore or Book while the operator leaves it alone because of
+// PausedAnnotation.
+const ConditionPaused = "Paused"
+
+// IsPaused reports whether obj carries PausedAnnotation set to "true".
+func IsPaused(obj metav1.Object) bool {
+	return obj.GetAnnotations()[PausedAnnotation] == "true"
+}
+
+// ConditionNamespaceReady reports whether the store's Namespace exists and carries the expected labels.
+const ConditionNamespaceReady = "NamespaceReady"
+
 // BookStoreSpec defines the desired state of BookStore
 type BookStoreSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 	// The following markers will use OpenAPI v3 schema to validate the value
 	// More info: https://book.kubebuilder.io/reference/markers/crd-validation.html
+
+	// copyPolicy decides which other stores may copy this store's Books. Without it every store may.
+	// +optional
+	CopyPolicy *CopyPolicy `json:"copyPolicy,omitempty"`
+}
+
+// CopyPolicyType is the kind of CopyPolicy.
+// +kubebuilder:validation:Enum=AllowAll;DenyAll;Selector
+type CopyPolicyType string
+
+const (
+	// CopyPolicyAllowAll lets every store copy the Books.
+	CopyPolicyAllowAll CopyPolicyType = "AllowAll"
+	// CopyPolicyDenyAll lets no other store copy the Books.
+	CopyPolicyDenyAll CopyPolicyType = "DenyAll"
+	// CopyPolicySelector lets the stores matched by storeSelector copy the Books.
+	CopyPolicySelector CopyPolicyType = "Selector"
+)
+
+// CopyPolicy declares which other stores may copy a store's Books. It is checked when a copy is
+// created or repointed, existing copies are left alone.
+type CopyPolicy struct {
+	// type is AllowAll, DenyAll or Selector.
+	// +kubebuilder:default=AllowAll
+	// +optional
+	Type CopyPolicyType `json:"type,omitempty"`
+
+	// storeSelector selects, by their labels, the BookStores that may copy when type is Selector.
+	// +optional
+	StoreSelector *metav1.LabelSelector `json:"storeSelector,omitempty"`
 }
 
 // BookStoreStatus defines the observed state of BookStore.
@@ -52,6 +110,14 @@ type BookStoreStatus struct {
 	// +listMapKey=type
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// observedGeneration is the metadata.generation the BookStore controller last reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// copyHolders lists, sorted, the other stores currently holding copies of this store's Books.
+	// +optional
+	CopyHolders []string `json:"copyHolders,omitempty"`
 }
 
 // +kubebuilder:object:root=true
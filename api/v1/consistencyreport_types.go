@@ -0,0 +1,125 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FindingType classifies an inconsistency found by the consistency auditor.
+// +kubebuilder:validation:Enum=DanglingCopyOf;WrongReferenceCount;OrphanStoreNamespace;UnmanagedBook;StuckTerminating
+type FindingType string
+
+const (
+	// FindingDanglingCopyOf is a copy whose spec.copyOf points at a Book that does not exist.
+	FindingDanglingCopyOf FindingType = "DanglingCopyOf"
+	// FindingWrongReferenceCount is an original whose status.referenceCount does not match its copies.
+	FindingWrongReferenceCount FindingType = "WrongReferenceCount"
+	// FindingOrphanStoreNamespace is a Namespace labeled for a BookStore that does not exist.
+	FindingOrphanStoreNamespace FindingType = "OrphanStoreNamespace"
+	// FindingUnmanagedBook is a Book in a namespace that no BookStore manages.
+	FindingUnmanagedBook FindingType = "UnmanagedBook"
+	// FindingStuckTerminating is a BookStore that has been terminating for too long.
+	FindingStuckTerminating FindingType = "StuckTerminating"
+)
+
+// Finding is a single inconsistency.
+type Finding struct {
+	// type classifies the finding.
+	// +required
+	Type FindingType `json:"type"`
+
+	// kind, namespace and name identify the object the finding is about.
+	// +required
+	Kind string `json:"kind"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// +required
+	Name string `json:"name"`
+
+	// message describes the inconsistency.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// repaired is true when the auditor fixed the inconsistency during this run.
+	// +optional
+	Repaired bool `json:"repaired,omitempty"`
+}
+
+// ConsistencyReportSpec defines the desired state of ConsistencyReport.
+// The report is written by the operator, so there is nothing to configure here.
+type ConsistencyReportSpec struct {
+}
+
+// ConsistencyReportStatus holds the outcome of the latest audit run.
+type ConsistencyReportStatus struct {
+	// lastRunTime is when the latest audit run finished.
+	// +optional
+	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
+
+	// findingCount is the number of inconsistencies found in the latest run, including the ones
+	// left out of findings once it is full.
+	// +optional
+	FindingCount int `json:"findingCount"`
+
+	// repairedCount is the number of findings the latest run repaired.
+	// +optional
+	RepairedCount int `json:"repairedCount,omitempty"`
+
+	// findings lists the inconsistencies found in the latest run, capped to keep the object small.
+	// +listType=atomic
+	// +optional
+	Findings []Finding `json:"findings,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Findings",type=integer,JSONPath=`.status.findingCount`
+// +kubebuilder:printcolumn:name="Repaired",type=integer,JSONPath=`.status.repairedCount`
+// +kubebuilder:printcolumn:name="Last Run",type=date,JSONPath=`.status.lastRunTime`
+
+// ConsistencyReport is the Schema for the consistencyreports API. The consistency auditor keeps a
+// single report up to date with what it found in its latest run.
+type ConsistencyReport struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of ConsistencyReport
+	// +optional
+	Spec ConsistencyReportSpec `json:"spec,omitzero"`
+
+	// status holds the findings of the latest audit run
+	// +optional
+	Status ConsistencyReportStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// ConsistencyReportList contains a list of ConsistencyReport
+type ConsistencyReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []ConsistencyReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ConsistencyReport{}, &ConsistencyReportList{})
+}
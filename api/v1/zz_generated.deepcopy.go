@@ -131,7 +131,7 @@ func (in *BookStore) DeepCopyInto(out *BookStore) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -188,6 +188,11 @@ func (in *BookStoreList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BookStoreSpec) DeepCopyInto(out *BookStoreSpec) {
 	*out = *in
+	if in.CopyPolicy != nil {
+		in, out := &in.CopyPolicy, &out.CopyPolicy
+		*out = new(CopyPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BookStoreSpec.
@@ -210,6 +215,11 @@ func (in *BookStoreStatus) DeepCopyInto(out *BookStoreStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CopyHolders != nil {
+		in, out := &in.CopyHolders, &out.CopyHolders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BookStoreStatus.
@@ -222,6 +232,104 @@ func (in *BookStoreStatus) DeepCopy() *BookStoreStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsistencyReport) DeepCopyInto(out *ConsistencyReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsistencyReport.
+func (in *ConsistencyReport) DeepCopy() *ConsistencyReport {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsistencyReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsistencyReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsistencyReportList) DeepCopyInto(out *ConsistencyReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ConsistencyReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsistencyReportList.
+func (in *ConsistencyReportList) DeepCopy() *ConsistencyReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsistencyReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsistencyReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsistencyReportSpec) DeepCopyInto(out *ConsistencyReportSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsistencyReportSpec.
+func (in *ConsistencyReportSpec) DeepCopy() *ConsistencyReportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsistencyReportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsistencyReportStatus) DeepCopyInto(out *ConsistencyReportStatus) {
+	*out = *in
+	if in.LastRunTime != nil {
+		in, out := &in.LastRunTime, &out.LastRunTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Findings != nil {
+		in, out := &in.Findings, &out.Findings
+		*out = make([]Finding, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsistencyReportStatus.
+func (in *ConsistencyReportStatus) DeepCopy() *ConsistencyReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsistencyReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CopyOf) DeepCopyInto(out *CopyOf) {
 	*out = *in
@@ -236,3 +344,38 @@ func (in *CopyOf) DeepCopy() *CopyOf {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CopyPolicy) DeepCopyInto(out *CopyPolicy) {
+	*out = *in
+	if in.StoreSelector != nil {
+		in, out := &in.StoreSelector, &out.StoreSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CopyPolicy.
+func (in *CopyPolicy) DeepCopy() *CopyPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CopyPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Finding) DeepCopyInto(out *Finding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Finding.
+func (in *Finding) DeepCopy() *Finding {
+	if in == nil {
+		return nil
+	}
+	out := new(Finding)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -17,26 +17,44 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
+	"log/slog"
 	"os"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+	"github.com/danieldanieltata/bookstore-operator/internal/config"
 	"github.com/danieldanieltata/bookstore-operator/internal/controller"
+	"github.com/danieldanieltata/bookstore-operator/internal/decisions"
+	"github.com/danieldanieltata/bookstore-operator/internal/dryrun"
+	"github.com/danieldanieltata/bookstore-operator/internal/metrics"
+	"github.com/danieldanieltata/bookstore-operator/internal/sharding"
+	"github.com/danieldanieltata/bookstore-operator/internal/stores"
+	"github.com/danieldanieltata/bookstore-operator/internal/tracing"
 	webhookv1 "github.com/danieldanieltata/bookstore-operator/internal/webhook/v1"
 	// +kubebuilder:scaffold:imports
 )
@@ -62,6 +80,13 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var allowUnmanagedBooks bool
+	var tracingOpts tracing.Options
+	var denialLogFile string
+	var configPath string
+	var watchNamespaces, watchNamespaceSelector string
+	var sharder sharding.Sharder
+	var dryRun bool
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -80,6 +105,33 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.BoolVar(&allowUnmanagedBooks, "allow-unmanaged-books", false,
+		"If set, the webhook admits Books in namespaces that are not managed by a BookStore.")
+	flag.StringVar(&configPath, "config", "",
+		"The path to an OperatorConfig file. Most fields are reloaded when the file changes. "+
+			"Leave empty to use the defaults.")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", "",
+		"A comma-separated list of namespaces to restrict the manager cache to. Leave empty, together with "+
+			"--watch-namespace-selector, to watch the whole cluster.")
+	flag.StringVar(&watchNamespaceSelector, "watch-namespace-selector", "",
+		"A label selector for namespaces to add to the watched set. It is resolved once at startup.")
+	flag.IntVar(&sharder.Count, "shard-count", 1,
+		"The number of shards BookStores are split across. Each shard runs its own leader-elected replicas.")
+	flag.IntVar(&sharder.Shard, "shard", 0,
+		"The shard this replica reconciles, from 0 to --shard-count minus 1.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"If set, the controllers send every write with dryRun=All and log it instead of changing the cluster, "+
+			"and the webhook warns instead of denying. It uses its own leader election lease.")
+	flag.StringVar(&tracingOpts.OTLPEndpoint, "otlp-endpoint", "",
+		"The host:port of an OTLP gRPC collector to export traces to. Leave empty to disable OTLP export.")
+	flag.BoolVar(&tracingOpts.OTLPInsecure, "otlp-insecure", false,
+		"If set, traces are sent to the OTLP collector without TLS.")
+	flag.StringVar(&tracingOpts.File, "trace-file", "",
+		"A file to write traces to as JSON for local debugging, or - for stdout. Leave empty to disable.")
+	flag.Float64Var(&tracingOpts.SampleRatio, "trace-sample-ratio", 1,
+		"The fraction of reconciles and admission requests that start a new trace.")
+	flag.StringVar(&denialLogFile, "denial-log-file", "-",
+		"A file to append webhook denials to as JSON lines, or - for stdout. Leave empty to disable.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -155,13 +207,74 @@ func main() {
 		metricsServerOptions.KeyName = metricsCertKey
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	if err := sharder.Validate(); err != nil {
+		setupLog.Error(err, "invalid sharding flags")
+		os.Exit(1)
+	}
+
+	// A nil watcher serves the defaults.
+	var operatorConfig *config.Watcher
+	if configPath != "" {
+		var err error
+		operatorConfig, err = config.NewWatcher(configPath)
+		if err != nil {
+			setupLog.Error(err, "unable to load operator configuration", "config", configPath)
+			os.Exit(1)
+		}
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracingOpts)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
+	var denialLog *slog.Logger
+	switch denialLogFile {
+	case "":
+	case "-":
+		denialLog = decisions.NewDenialLog(os.Stdout)
+	default:
+		f, err := os.OpenFile(denialLogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			setupLog.Error(err, "unable to open the denial log", "path", denialLogFile)
+			os.Exit(1)
+		}
+		// The file stays open for the life of the process.
+		denialLog = decisions.NewDenialLog(f)
+	}
+
+	restConfig := ctrl.GetConfigOrDie()
+	tracing.WrapConfig(restConfig)
+
+	scope, err := resolveWatchScope(restConfig, watchNamespaces, watchNamespaceSelector)
+	if err != nil {
+		setupLog.Error(err, "unable to resolve watched namespaces")
+		os.Exit(1)
+	}
+	var cacheOpts cache.Options
+	if scope.Restricted() {
+		setupLog.Info("restricting the cache to namespaces", "namespaces", scope.Namespaces())
+		cacheOpts.DefaultNamespaces = make(map[string]cache.Config)
+		for _, namespace := range scope.Namespaces() {
+			cacheOpts.DefaultNamespaces[namespace] = cache.Config{}
+		}
+	}
+
+	// Each shard elects its own leader, so its replicas fail over independently of the others. A
+	// dry-run manager takes a separate lease, so it can run next to the real one without replacing it.
+	leaderElectionID := sharder.LeaderElectionID("1a346b5a.bookstore.example.com")
+	if dryRun {
+		leaderElectionID = "dry-run." + leaderElectionID
+	}
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  cacheOpts,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "1a346b5a.bookstore.example.com",
+		LeaderElectionID:       leaderElectionID,
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -179,27 +292,94 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The controllers and webhooks look stores and copies up by field instead of listing them all.
+	if err := stores.IndexFields(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		setupLog.Error(err, "unable to index fields")
+		os.Exit(1)
+	}
+
+	if operatorConfig != nil {
+		if err := mgr.Add(operatorConfig); err != nil {
+			setupLog.Error(err, "unable to watch operator configuration")
+			os.Exit(1)
+		}
+	}
+
+	// Book inventory metrics are computed from the cache on every scrape, next to the
+	// cleanup metrics the controllers record themselves. See internal/metrics.
+	// They cover the whole cluster, so with sharding only shard 0 exports them to avoid counting
+	// every Book once per shard.
+	if sharder.Shard == 0 {
+		crmetrics.Registry.MustRegister(metrics.NewInventoryCollector(mgr.GetClient()))
+	}
+
+	// In dry-run mode the controllers still read from the cache, but their writes only reach the
+	// API server's validation and their Events only reach the log.
+	controllerClient := mgr.GetClient()
+	bookStoreRecorder := mgr.GetEventRecorder("bookstore-controller")
+	bookRecorder := mgr.GetEventRecorder("book-controller")
+	if dryRun {
+		setupLog.Info("running in dry-run mode, no changes will be made to the cluster")
+		controllerClient = dryrun.NewClient(controllerClient)
+		bookStoreRecorder = dryrun.NewRecorder("bookstore-controller")
+		bookRecorder = dryrun.NewRecorder("book-controller")
+	}
+
 	if err := (&controller.BookStoreReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   controllerClient,
+		Scheme:   mgr.GetScheme(),
+		Recorder: bookStoreRecorder,
+		Config:   operatorConfig,
+		Scope:    scope,
+		Sharder:  sharder,
+		DryRun:   dryRun,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "BookStore")
 		os.Exit(1)
 	}
 	if err := (&controller.BookReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   controllerClient,
+		Scheme:   mgr.GetScheme(),
+		Recorder: bookRecorder,
+		Config:   operatorConfig,
+		Scope:    scope,
+		Sharder:  sharder,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Book")
 		os.Exit(1)
 	}
+	// The auditor checks the whole cluster, so like the inventory metrics it only runs on shard 0.
+	if sharder.Shard == 0 {
+		if err := mgr.Add(&controller.ConsistencyAuditor{
+			Client: controllerClient,
+			Config: operatorConfig,
+			Scope:  scope,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up consistency auditor")
+			os.Exit(1)
+		}
+	}
 	// nolint:goconst
-	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
-		if err := webhookv1.SetupBookWebhookWithManager(mgr); err != nil {
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" && operatorConfig.Current().BookWebhookEnabled() {
+		if err := webhookv1.SetupBookWebhookWithManager(mgr, webhookv1.BookWebhookOptions{
+			AllowUnmanagedNamespaces: allowUnmanagedBooks,
+			Config:                   operatorConfig,
+			DryRun:                   dryRun,
+			DenialLog:                denialLog,
+		}); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Book")
 			os.Exit(1)
 		}
 	}
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" && operatorConfig.Current().BookStoreWebhookEnabled() {
+		if err := webhookv1.SetupBookStoreWebhookWithManager(mgr, webhookv1.BookStoreWebhookOptions{
+			DryRun:    dryRun,
+			DenialLog: denialLog,
+		}); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "BookStore")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -216,4 +396,46 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		setupLog.Error(err, "problem flushing traces")
+	}
+}
+
+// resolveWatchScope combines the listed namespaces with the ones matching selector. Namespaces are
+// read once with a direct client, so a namespace created later is only watched after a restart.
+func resolveWatchScope(restConfig *rest.Config, list, selector string) (controller.WatchScope, error) {
+	var namespaces []string
+	for namespace := range strings.SplitSeq(list, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	if selector == "" {
+		return controller.NewWatchScope(namespaces...), nil
+	}
+
+	labelSelector, err := labels.Parse(selector)
+	if err != nil {
+		return controller.WatchScope{}, fmt.Errorf("invalid --watch-namespace-selector: %w", err)
+	}
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return controller.WatchScope{}, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	var namespaceList corev1.NamespaceList
+	if err := c.List(ctx, &namespaceList, client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+		return controller.WatchScope{}, fmt.Errorf("listing namespaces for --watch-namespace-selector: %w", err)
+	}
+	for _, namespace := range namespaceList.Items {
+		namespaces = append(namespaces, namespace.Name)
+	}
+	if len(namespaces) == 0 {
+		return controller.WatchScope{}, fmt.Errorf("no namespaces match --watch-namespace-selector %q", selector)
+	}
+	return controller.NewWatchScope(namespaces...), nil
 }
@@ -0,0 +1,228 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config loads the operator-wide configuration file and keeps it current while the
+// manager runs.
+//
+// The file is versioned, for example:
+//
+//	apiVersion: bookstore.example.com/v1alpha1
+//	kind: OperatorConfig
+//	controllers:
+//	  book:
+//	    maxConcurrentReconciles: 4
+//	    requeueInterval: 10m
+//	cleanup:
+//	  batchSize: 100
+//	  deletionPolicy: Delete
+//	audit:
+//	  interval: 10m
+//	  repair: true
+//
+// Fields marked "requires restart" are read once when the manager starts. Every other field is
+// picked up as soon as the file changes.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// APIVersion and Kind identify the configuration file format.
+const (
+	APIVersion = "bookstore.example.com/v1alpha1"
+	Kind       = "OperatorConfig"
+)
+
+// DeletionPolicy decides what happens to copies in other stores when a BookStore is deleted.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes remote copies together with the store's Books.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyOrphan leaves remote copies in place with a dangling spec.copyOf.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// OperatorConfig is the operator-wide configuration.
+type OperatorConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Controllers ControllersConfig `json:"controllers,omitempty"`
+	Webhooks    WebhooksConfig    `json:"webhooks,omitempty"`
+	Cleanup     CleanupConfig     `json:"cleanup,omitempty"`
+	Audit       AuditConfig       `json:"audit,omitempty"`
+}
+
+// ControllersConfig holds the settings of each controller.
+type ControllersConfig struct {
+	BookStore ControllerConfig `json:"bookStore,omitempty"`
+	Book      ControllerConfig `json:"book,omitempty"`
+}
+
+// ControllerConfig holds the settings of a single controller.
+type ControllerConfig struct {
+	// MaxConcurrentReconciles is the number of workers. Requires restart.
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles,omitempty"`
+	// RequeueInterval re-runs a successful reconcile after this long to catch drift. Zero disables it.
+	RequeueInterval metav1.Duration `json:"requeueInterval,omitempty"`
+}
+
+// WebhooksConfig holds the admission webhook toggles.
+type WebhooksConfig struct {
+	Book      BookWebhookConfig      `json:"book,omitempty"`
+	BookStore BookStoreWebhookConfig `json:"bookStore,omitempty"`
+}
+
+// BookWebhookConfig holds the Book webhook toggles.
+type BookWebhookConfig struct {
+	// Enabled registers the Book webhook. Requires restart.
+	Enabled *bool `json:"enabled,omitempty"`
+	// AllowUnmanagedNamespaces admits Books in namespaces that no BookStore manages.
+	AllowUnmanagedNamespaces bool `json:"allowUnmanagedNamespaces,omitempty"`
+	// LookupTimeout bounds the API server lookup of a copy's original during admission.
+	LookupTimeout metav1.Duration `json:"lookupTimeout,omitempty"`
+	// ImmutableCopyOf rejects any change to spec.copyOf after a Book is created.
+	ImmutableCopyOf bool `json:"immutableCopyOf,omitempty"`
+	// KnownGenres is the genre taxonomy. A Book with another genre is admitted with a warning. An
+	// empty list turns the warning off.
+	KnownGenres []string `json:"knownGenres,omitempty"`
+}
+
+// BookStoreWebhookConfig holds the BookStore webhook toggles.
+type BookStoreWebhookConfig struct {
+	// Enabled registers the BookStore webhook. Requires restart.
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// CleanupConfig holds the BookStore finalizer cleanup settings.
+type CleanupConfig struct {
+	// BatchSize caps how many Books a single reconcile deletes, the rest is done on requeue.
+	// Zero deletes everything in one pass.
+	BatchSize int `json:"batchSize,omitempty"`
+	// DeletionPolicy decides what happens to copies of the store's Books in other stores.
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// AuditConfig holds the consistency auditor settings.
+type AuditConfig struct {
+	// Interval is how often the auditor scans the cluster. Zero disables it.
+	Interval metav1.Duration `json:"interval,omitempty"`
+	// Repair fixes the findings that are safe to fix automatically: a wrong referenceCount is recounted.
+	Repair bool `json:"repair,omitempty"`
+	// StuckTerminatingAfter is how long a BookStore may be terminating before it is reported.
+	StuckTerminatingAfter metav1.Duration `json:"stuckTerminatingAfter,omitempty"`
+}
+
+// Default returns the configuration used when no file is given.
+func Default() *OperatorConfig {
+	enabled := true
+	return &OperatorConfig{
+		TypeMeta: metav1.TypeMeta{APIVersion: APIVersion, Kind: Kind},
+		Controllers: ControllersConfig{
+			BookStore: ControllerConfig{MaxConcurrentReconciles: 1},
+			Book:      ControllerConfig{MaxConcurrentReconciles: 1},
+		},
+		Webhooks: WebhooksConfig{BookStore: BookStoreWebhookConfig{Enabled: &enabled}, Book: BookWebhookConfig{
+			Enabled:       &enabled,
+			LookupTimeout: metav1.Duration{Duration: 2 * time.Second},
+			KnownGenres: []string{
+				"Biography", "Children", "Fantasy", "Fiction", "History", "Horror", "Mystery",
+				"Non-Fiction", "Poetry", "Romance", "Science", "Science Fiction", "Thriller",
+			},
+		}},
+		Cleanup: CleanupConfig{DeletionPolicy: DeletionPolicyDelete},
+		Audit: AuditConfig{
+			Interval:              metav1.Duration{Duration: 10 * time.Minute},
+			StuckTerminatingAfter: metav1.Duration{Duration: 15 * time.Minute},
+		},
+	}
+}
+
+// BookWebhookEnabled reports whether the Book webhook should be registered.
+func (c *OperatorConfig) BookWebhookEnabled() bool {
+	return c.Webhooks.Book.Enabled == nil || *c.Webhooks.Book.Enabled
+}
+
+// BookStoreWebhookEnabled reports whether the BookStore webhook should be registered.
+func (c *OperatorConfig) BookStoreWebhookEnabled() bool {
+	return c.Webhooks.BookStore.Enabled == nil || *c.Webhooks.BookStore.Enabled
+}
+
+// Load reads and validates the configuration file at path. Fields missing from the file keep
+// their defaults, unknown fields are rejected so typos do not go unnoticed.
+func Load(path string) (*OperatorConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := Default()
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate checks the configuration and reports every problem it finds.
+func (c *OperatorConfig) Validate() error {
+	var errs []error
+	if c.APIVersion != APIVersion || c.Kind != Kind {
+		errs = append(errs, fmt.Errorf("expected apiVersion %s and kind %s, got %s %s", APIVersion, Kind, c.APIVersion, c.Kind))
+	}
+	for name, controller := range map[string]ControllerConfig{
+		"bookStore": c.Controllers.BookStore,
+		"book":      c.Controllers.Book,
+	} {
+		if controller.MaxConcurrentReconciles < 1 {
+			errs = append(errs, fmt.Errorf("controllers.%s.maxConcurrentReconciles must be at least 1", name))
+		}
+		if controller.RequeueInterval.Duration < 0 {
+			errs = append(errs, fmt.Errorf("controllers.%s.requeueInterval must not be negative", name))
+		}
+	}
+	if c.Webhooks.Book.LookupTimeout.Duration <= 0 {
+		errs = append(errs, errors.New("webhooks.book.lookupTimeout must be positive"))
+	}
+	if c.Cleanup.BatchSize < 0 {
+		errs = append(errs, errors.New("cleanup.batchSize must not be negative"))
+	}
+	switch c.Cleanup.DeletionPolicy {
+	case DeletionPolicyDelete, DeletionPolicyOrphan:
+	default:
+		errs = append(errs, fmt.Errorf("cleanup.deletionPolicy must be %s or %s, got %q",
+			DeletionPolicyDelete, DeletionPolicyOrphan, c.Cleanup.DeletionPolicy))
+	}
+	if c.Audit.Interval.Duration < 0 {
+		errs = append(errs, errors.New("audit.interval must not be negative"))
+	}
+	if c.Audit.StuckTerminatingAfter.Duration <= 0 {
+		errs = append(errs, errors.New("audit.stuckTerminatingAfter must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+// RequeueAfter returns the interval a successful reconcile is re-run after, zero for never.
+func (c ControllerConfig) RequeueAfter() time.Duration {
+	return c.RequeueInterval.Duration
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad_KeepsDefaultsForMissingFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, `apiVersion: bookstore.example.com/v1alpha1
+kind: OperatorConfig
+controllers:
+  book:
+    requeueInterval: 10m
+cleanup:
+  batchSize: 50
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+	if got := cfg.Controllers.Book.RequeueAfter(); got != 10*time.Minute {
+		t.Errorf("unexpected book requeue interval: %s", got)
+	}
+	if got := cfg.Controllers.Book.MaxConcurrentReconciles; got != 1 {
+		t.Errorf("expected default maxConcurrentReconciles, got %d", got)
+	}
+	if cfg.Cleanup.BatchSize != 50 || cfg.Cleanup.DeletionPolicy != DeletionPolicyDelete {
+		t.Errorf("unexpected cleanup config: %+v", cfg.Cleanup)
+	}
+	if !cfg.BookWebhookEnabled() {
+		t.Error("expected the Book webhook to stay enabled by default")
+	}
+	if got := cfg.Webhooks.Book.LookupTimeout.Duration; got != 2*time.Second {
+		t.Errorf("expected the default lookup timeout, got %s", got)
+	}
+}
+
+func TestLoad_RejectsInvalidConfig(t *testing.T) {
+	cases := map[string]struct {
+		content string
+		want    []string
+	}{
+		"wrong kind": {
+			content: "apiVersion: bookstore.example.com/v1alpha1\nkind: Something\n",
+			want:    []string{"expected apiVersion bookstore.example.com/v1alpha1 and kind OperatorConfig"},
+		},
+		"unknown field": {
+			content: "apiVersion: bookstore.example.com/v1alpha1\nkind: OperatorConfig\ncleanup:\n  batchLimit: 5\n",
+			want:    []string{"unknown field"},
+		},
+		"every bad value at once": {
+			content: `apiVersion: bookstore.example.com/v1alpha1
+kind: OperatorConfig
+controllers:
+  bookStore:
+    maxConcurrentReconciles: 0
+webhooks:
+  book:
+    lookupTimeout: 0s
+cleanup:
+  batchSize: -1
+  deletionPolicy: Keep
+audit:
+  interval: -1m
+  stuckTerminatingAfter: 0s
+`,
+			want: []string{
+				"controllers.bookStore.maxConcurrentReconciles must be at least 1",
+				"webhooks.book.lookupTimeout must be positive",
+				"cleanup.batchSize must not be negative",
+				`cleanup.deletionPolicy must be Delete or Orphan, got "Keep"`,
+				"audit.interval must not be negative",
+				"audit.stuckTerminatingAfter must be positive",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			writeConfig(t, path, tc.content)
+
+			_, err := Load(path)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected %q in error: %s", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestWatcher_ReloadKeepsRestartOnlyFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "apiVersion: bookstore.example.com/v1alpha1\nkind: OperatorConfig\n")
+
+	w, err := NewWatcher(path)
+	if err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+
+	writeConfig(t, path, `apiVersion: bookstore.example.com/v1alpha1
+kind: OperatorConfig
+controllers:
+  book:
+    maxConcurrentReconciles: 8
+webhooks:
+  book:
+    allowUnmanagedNamespaces: true
+  bookStore:
+    enabled: false
+`)
+	w.reload()
+
+	cfg := w.Current()
+	if !cfg.Webhooks.Book.AllowUnmanagedNamespaces {
+		t.Error("expected allowUnmanagedNamespaces to be reloaded")
+	}
+	if got := cfg.Controllers.Book.MaxConcurrentReconciles; got != 1 {
+		t.Errorf("expected maxConcurrentReconciles to keep its running value, got %d", got)
+	}
+	if !cfg.BookStoreWebhookEnabled() {
+		t.Error("expected the BookStore webhook toggle to keep its running value")
+	}
+
+	writeConfig(t, path, "not: [valid")
+	w.reload()
+	if !w.Current().Webhooks.Book.AllowUnmanagedNamespaces {
+		t.Error("expected an invalid file to keep the previous configuration")
+	}
+}
+
+func TestWatcher_NilServesDefaults(t *testing.T) {
+	var w *Watcher
+	if got := w.Current().Cleanup.DeletionPolicy; got != DeletionPolicyDelete {
+		t.Errorf("unexpected default deletion policy: %s", got)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var watcherLog = logf.Log.WithName("operator-config")
+
+// Watcher holds the configuration in effect and reloads it when the file changes. A nil Watcher
+// serves the defaults, so components built without a configuration file need no special case.
+type Watcher struct {
+	path    string
+	current atomic.Pointer[OperatorConfig]
+}
+
+// NewWatcher loads path and returns a Watcher serving it. Add the Watcher to the manager to
+// have it follow changes to the file.
+func NewWatcher(path string) (*Watcher, error) {
+	cfg, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{path: path}
+	w.current.Store(cfg)
+	return w, nil
+}
+
+// Current returns the configuration in effect. Callers must not modify it.
+func (w *Watcher) Current() *OperatorConfig {
+	if w == nil {
+		return Default()
+	}
+	return w.current.Load()
+}
+
+// Start watches the directory holding the file until ctx is done. Watching the directory
+// rather than the file also catches the symlink swap a ConfigMap volume does on update.
+func (w *Watcher) Start(ctx context.Context) error {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fsWatcher.Close() }()
+	if err := fsWatcher.Add(filepath.Dir(w.path)); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-fsWatcher.Events:
+			if event.Has(fsnotify.Chmod) {
+				continue
+			}
+			w.reload()
+		case err := <-fsWatcher.Errors:
+			watcherLog.Error(err, "Error watching configuration file", "path", w.path)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Every replica serves webhooks,
+// so every replica follows the file.
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// reload swaps in the file's configuration when it is valid. Fields that require a restart
+// keep their running values, so Current always describes what is actually in effect.
+func (w *Watcher) reload() {
+	cfg, err := Load(w.path)
+	if err != nil {
+		watcherLog.Error(err, "Ignoring invalid configuration, keeping the previous one", "path", w.path)
+		return
+	}
+
+	running := w.current.Load()
+	if cfg.Controllers.BookStore.MaxConcurrentReconciles != running.Controllers.BookStore.MaxConcurrentReconciles ||
+		cfg.Controllers.Book.MaxConcurrentReconciles != running.Controllers.Book.MaxConcurrentReconciles ||
+		cfg.BookWebhookEnabled() != running.BookWebhookEnabled() ||
+		cfg.BookStoreWebhookEnabled() != running.BookStoreWebhookEnabled() {
+		watcherLog.Info("maxConcurrentReconciles and webhooks.*.enabled only change on restart", "path", w.path)
+	}
+	cfg.Controllers.BookStore.MaxConcurrentReconciles = running.Controllers.BookStore.MaxConcurrentReconciles
+	cfg.Controllers.Book.MaxConcurrentReconciles = running.Controllers.Book.MaxConcurrentReconciles
+	cfg.Webhooks.Book.Enabled = running.Webhooks.Book.Enabled
+	cfg.Webhooks.BookStore.Enabled = running.Webhooks.BookStore.Enabled
+
+	w.current.Store(cfg)
+	watcherLog.Info("Configuration reloaded", "path", w.path)
+}
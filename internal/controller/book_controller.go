@@ -19,27 +19,60 @@ package controller
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+	"github.com/danieldanieltata/bookstore-operator/internal/config"
+	"github.com/danieldanieltata/bookstore-operator/internal/sharding"
+	"github.com/danieldanieltata/bookstore-operator/internal/stores"
+	"github.com/danieldanieltata/bookstore-operator/internal/tracing"
+)
+
+// fieldManager is the field owner recorded for every write the operator makes.
+const fieldManager = "bookstore-operator"
+
+// Event reasons recorded on Books.
+const (
+	reasonReferenceCountChanged = "ReferenceCountChanged"
+	reasonReferenceCountFailed  = "ReferenceCountFailed"
+	reasonUnmanaged             = "Unmanaged"
+	reasonOriginalNotWatched    = "OriginalNotWatched"
 )
 
 // BookReconciler reconciles a Book object
 type BookReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
+	Config   *config.Watcher
+	// Scope is the set of namespaces the manager cache covers. The zero value is the whole cluster.
+	Scope WatchScope
+	// Sharder selects the Books this replica reconciles. The zero value reconciles all of them.
+	Sharder sharding.Sharder
 }
 
 // +kubebuilder:rbac:groups=bookstore.example.com,resources=books,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=bookstore.example.com,resources=books/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=bookstore.example.com,resources=books/finalizers,verbs=update
+// +kubebuilder:rbac:groups=bookstore.example.com,resources=bookstores,verbs=get;list;watch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -55,8 +88,26 @@ func (r *BookReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 
 	log.Info("Reconciling Book", "request", req)
 
+	// A copy can point at an original the cache does not cover, and a Get for it would fail on
+	// every retry. The copy reports it through its OutsideWatchScope condition instead.
+	if !r.Scope.Contains(req.Namespace) {
+		log.Info("Book is outside the watched namespaces, skipping reconciliation")
+		return ctrl.Result{}, nil
+	}
+
+	// The copyOf watch fires on every replica, so each original is recounted by the shard that owns
+	// its namespace, from a cache that still holds the copies of every shard.
+	owned, err := r.Sharder.OwnsNamespace(ctx, r.Client, req.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !owned {
+		log.V(1).Info("Book belongs to another shard, skipping reconciliation")
+		return ctrl.Result{}, nil
+	}
+
 	book := &bookstoreexamplecomv1.Book{}
-	err := r.Get(ctx, types.NamespacedName{Name: req.Name, Namespace: req.Namespace}, book)
+	err = r.Get(ctx, types.NamespacedName{Name: req.Name, Namespace: req.Namespace}, book)
 
 	if err != nil && errors.IsNotFound(err) {
 		log.Info("Book not found, skipping reconciliation")
@@ -67,62 +118,334 @@ func (r *BookReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 
 	log.Info("Book found", "book", book.Name, "namespace", book.Namespace)
 
-	var allBooks bookstoreexamplecomv1.BookList
-	if err := r.List(ctx, &allBooks); err != nil {
+	bookstore, err := stores.ForNamespace(ctx, r.Client, book.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// A paused Book, or any Book in a paused store, keeps its referenceCount and conditions as they
+	// are. The original is recounted once the annotation is removed, since that is an update too.
+	if reason := pausedReason(book, bookstore); reason != "" {
+		log.Info("Book is paused, skipping reconciliation", "reason", reason)
+		return ctrl.Result{}, r.updatePausedCondition(ctx, book, reason)
+	}
+	if err := r.updatePausedCondition(ctx, book, ""); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.updateUnmanagedCondition(ctx, book, bookstore); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.updateWatchScopeCondition(ctx, book); err != nil {
 		return ctrl.Result{}, err
 	}
 
 	if book.Spec.CopyOf == nil {
 		log.Info("Book is original book, counting copies")
 
-		referenceCount := 0
-		for _, otherBook := range allBooks.Items {
-			if otherBook.Spec.CopyOf == nil {
-				continue
-			}
-			if otherBook.Spec.CopyOf.Namespace == book.Namespace && otherBook.Spec.CopyOf.Name == book.Name {
-				referenceCount++
-			}
+		if err := r.updateReferenceCount(ctx, req.NamespacedName); err != nil {
+			r.Recorder.Eventf(book, nil, corev1.EventTypeWarning, reasonReferenceCountFailed, "Update",
+				"Failed to update referenceCount: %v", err)
+			return ctrl.Result{}, err
 		}
+	}
+
+	if book.Status.ObservedGeneration != book.Generation {
+		patch := client.MergeFrom(book.DeepCopy())
+		book.Status.ObservedGeneration = book.Generation
+		if err := r.Status().Patch(ctx, book, patch, client.FieldOwner(fieldManager)); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: r.Config.Current().Controllers.Book.RequeueAfter()}, nil
+}
+
+// updateReferenceCount recounts the copies of the original Book and records an Event when
+// status.referenceCount changed.
+func (r *BookReconciler) updateReferenceCount(ctx context.Context, key types.NamespacedName) error {
+	book, previous, err := patchReferenceCount(ctx, r.Client, key)
+	if err != nil || book == nil {
+		return err
+	}
+
+	logf.FromContext(ctx).Info("ReferenceCount updated", "book", book.Name, "referenceCount", book.Status.ReferenceCount)
+	r.Recorder.Eventf(book, nil, corev1.EventTypeNormal, reasonReferenceCountChanged, "Update",
+		"referenceCount changed from %d to %d", previous, book.Status.ReferenceCount)
+	return nil
+}
 
+// patchReferenceCount recounts the copies of the original Book and writes status.referenceCount with
+// a merge patch that is rejected when the Book changed since it was read. The count is recomputed on
+// every attempt, so a retry never writes a stale value. It returns the patched Book and the count it
+// replaced, or a nil Book when there was nothing to write.
+func patchReferenceCount(ctx context.Context, c client.Client, key types.NamespacedName) (*bookstoreexamplecomv1.Book, int, error) {
+	log := logf.FromContext(ctx)
+
+	var patched *bookstoreexamplecomv1.Book
+	var previous int
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		patched = nil
+		book := &bookstoreexamplecomv1.Book{}
+		if err := c.Get(ctx, key, book); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		var allBooks bookstoreexamplecomv1.BookList
+		if err := c.List(ctx, &allBooks); err != nil {
+			return err
+		}
+
+		referenceCount := countReferences(book, allBooks.Items)
 		log.Info("Counting copies for original book", "book", book.Name, "referenceCount", referenceCount)
 
-		if book.Status.ReferenceCount != referenceCount {
-			book.Status.ReferenceCount = referenceCount
-			err = r.Status().Update(ctx, book)
-			if err != nil {
-				return ctrl.Result{}, err
-			}
+		if book.Status.ReferenceCount == referenceCount {
+			return nil
+		}
+
+		// The patch carries the resourceVersion it was computed from, so a count worked out from a
+		// stale cache gets a conflict and is recounted instead of overwriting a newer value.
+		patch := client.MergeFromWithOptions(book.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		previous = book.Status.ReferenceCount
+		book.Status.ReferenceCount = referenceCount
+		if err := c.Status().Patch(ctx, book, patch, client.FieldOwner(fieldManager)); err != nil {
+			return err
+		}
+		patched = book
+		return nil
+	})
+	return patched, previous, err
+}
+
+// updateUnmanagedCondition flags Books that live outside every BookStore namespace, typically
+// ones created before the webhook enforced it, and clears the flag once a store adopts the namespace.
+func (r *BookReconciler) updateUnmanagedCondition(ctx context.Context, book *bookstoreexamplecomv1.Book,
+	bookstore *bookstoreexamplecomv1.BookStore) error {
+	patch := client.MergeFrom(book.DeepCopy())
+	var changed bool
+	if bookstore == nil {
+		logf.FromContext(ctx).Info("Book is not in a BookStore namespace", "book", book.Name, "namespace", book.Namespace)
+		changed = meta.SetStatusCondition(&book.Status.Conditions, metav1.Condition{
+			Type:               bookstoreexamplecomv1.ConditionUnmanaged,
+			Status:             metav1.ConditionTrue,
+			Reason:             "NoBookStore",
+			Message:            "no BookStore manages namespace " + book.Namespace,
+			ObservedGeneration: book.Generation,
+		})
+	} else {
+		changed = meta.RemoveStatusCondition(&book.Status.Conditions, bookstoreexamplecomv1.ConditionUnmanaged)
+	}
+	if !changed {
+		return nil
+	}
+	if err := r.Status().Patch(ctx, book, patch, client.FieldOwner(fieldManager)); err != nil {
+		return err
+	}
+	if bookstore == nil {
+		r.Recorder.Eventf(book, nil, corev1.EventTypeWarning, reasonUnmanaged, "Check",
+			"Namespace %s is not managed by any BookStore, this Book will not be cleaned up", book.Namespace)
+	}
+	return nil
+}
 
-			log.Info("ReferenceCount updated", "book", book.Name, "referenceCount", referenceCount)
+// pausedReason returns why book is paused, or "" when it is not.
+func pausedReason(book *bookstoreexamplecomv1.Book, bookstore *bookstoreexamplecomv1.BookStore) string {
+	switch {
+	case bookstoreexamplecomv1.IsPaused(book):
+		return "Annotated"
+	case bookstore != nil && bookstoreexamplecomv1.IsPaused(bookstore):
+		return "BookStorePaused"
+	default:
+		return ""
+	}
+}
+
+// updatePausedCondition sets the Paused condition with reason, or removes it when reason is empty,
+// and records an Event when it flips.
+func (r *BookReconciler) updatePausedCondition(ctx context.Context, book *bookstoreexamplecomv1.Book, reason string) error {
+	patch := client.MergeFrom(book.DeepCopy())
+	var changed bool
+	if reason != "" {
+		message := "reconciliation is paused by the " + bookstoreexamplecomv1.PausedAnnotation + " annotation"
+		if reason == "BookStorePaused" {
+			message += " on BookStore " + book.Namespace
 		}
+		changed = meta.SetStatusCondition(&book.Status.Conditions, metav1.Condition{
+			Type:               bookstoreexamplecomv1.ConditionPaused,
+			Status:             metav1.ConditionTrue,
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: book.Generation,
+		})
+	} else {
+		changed = meta.RemoveStatusCondition(&book.Status.Conditions, bookstoreexamplecomv1.ConditionPaused)
+	}
+	if !changed {
+		return nil
+	}
+	if err := r.Status().Patch(ctx, book, patch, client.FieldOwner(fieldManager)); err != nil {
+		return err
 	}
+	if reason != "" {
+		r.Recorder.Eventf(book, nil, corev1.EventTypeNormal, reasonPaused, "Pause", "Reconciliation paused (%s)", reason)
+	} else {
+		r.Recorder.Eventf(book, nil, corev1.EventTypeNormal, reasonResumed, "Resume", "Reconciliation resumed")
+	}
+	return nil
+}
+
+// updateWatchScopeCondition flags copies whose original is outside the watched namespaces. The
+// original's referenceCount is then kept by whichever operator instance watches it, if any.
+func (r *BookReconciler) updateWatchScopeCondition(ctx context.Context, book *bookstoreexamplecomv1.Book) error {
+	outside := book.Spec.CopyOf != nil && book.Spec.CopyOf.Namespace != "" && !r.Scope.Contains(book.Spec.CopyOf.Namespace)
+
+	patch := client.MergeFrom(book.DeepCopy())
+	var changed bool
+	if outside {
+		logf.FromContext(ctx).Info("Original Book is outside the watched namespaces",
+			"book", book.Name, "copyOf", book.Spec.CopyOf)
+		changed = meta.SetStatusCondition(&book.Status.Conditions, metav1.Condition{
+			Type:               bookstoreexamplecomv1.ConditionOutsideWatchScope,
+			Status:             metav1.ConditionTrue,
+			Reason:             reasonOriginalNotWatched,
+			Message:            "namespace " + book.Spec.CopyOf.Namespace + " is not watched by this operator",
+			ObservedGeneration: book.Generation,
+		})
+	} else {
+		changed = meta.RemoveStatusCondition(&book.Status.Conditions, bookstoreexamplecomv1.ConditionOutsideWatchScope)
+	}
+	if !changed {
+		return nil
+	}
+	if err := r.Status().Patch(ctx, book, patch, client.FieldOwner(fieldManager)); err != nil {
+		return err
+	}
+	if outside {
+		r.Recorder.Eventf(book, nil, corev1.EventTypeWarning, reasonOriginalNotWatched, "Check",
+			"Original Book %s/%s is outside the watched namespaces, it will not count this copy",
+			book.Spec.CopyOf.Namespace, book.Spec.CopyOf.Name)
+	}
+	return nil
+}
 
-	return ctrl.Result{}, nil
+// booksForBookStore enqueues the Books in a store's namespace so their Unmanaged condition
+// follows the BookStore being created or removed.
+func (r *BookReconciler) booksForBookStore(ctx context.Context, obj client.Object) []reconcile.Request {
+	var books bookstoreexamplecomv1.BookList
+	if err := r.List(ctx, &books, client.InNamespace(obj.GetName())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list Books for BookStore", "bookstore", obj.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(books.Items))
+	for _, book := range books.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: book.Namespace, Name: book.Name},
+		})
+	}
+	return requests
+}
+
+// countReferences returns how many of books are copies of original.
+func countReferences(original *bookstoreexamplecomv1.Book, books []bookstoreexamplecomv1.Book) int {
+	referenceCount := 0
+	for _, otherBook := range books {
+		if otherBook.Spec.CopyOf == nil {
+			continue
+		}
+		if otherBook.Spec.CopyOf.Namespace == original.Namespace && otherBook.Spec.CopyOf.Name == original.Name {
+			referenceCount++
+		}
+	}
+	return referenceCount
+}
+
+// specOrMetadataChanged lets through every create and delete, and the updates that change the spec,
+// labels or annotations. Status-only updates, including the controllers' own writes, are dropped.
+func specOrMetadataChanged() predicate.Predicate {
+	return predicate.Or(
+		predicate.GenerationChangedPredicate{},
+		predicate.AnnotationChangedPredicate{},
+		predicate.LabelChangedPredicate{},
+	)
+}
+
+// copyReferenceChanged lets through the events that can change which original a copy counts
+// toward: creating or deleting a copy, and spec updates. A status update to a copy never does.
+func copyReferenceChanged() predicate.Predicate {
+	isCopy := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		book, ok := obj.(*bookstoreexamplecomv1.Book)
+		return ok && book.Spec.CopyOf != nil
+	})
+	return predicate.Funcs{
+		CreateFunc:  isCopy.Create,
+		DeleteFunc:  isCopy.Delete,
+		GenericFunc: isCopy.Generic,
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			// The old object is checked too, so a copy that becomes an original still recounts the
+			// Book it used to point at.
+			return (isCopy.Generic(event.GenericEvent{Object: e.ObjectOld}) ||
+				isCopy.Generic(event.GenericEvent{Object: e.ObjectNew})) &&
+				predicate.GenerationChangedPredicate{}.Update(e)
+		},
+	}
+}
+
+// enqueueCopyOfTargets enqueues the originals a copy points at, so the original's reconcile is the
+// single place that updates referenceCount. On update both the old and the new target are enqueued:
+// repointing a copy from A to B recounts A as well as B, and so does turning a copy into an original.
+func (r *BookReconciler) enqueueCopyOfTargets() handler.EventHandler {
+	return handler.Funcs{
+		CreateFunc: func(_ context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			r.enqueueCopyOfTarget(q, e.Object)
+		},
+		UpdateFunc: func(_ context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			r.enqueueCopyOfTarget(q, e.ObjectOld)
+			r.enqueueCopyOfTarget(q, e.ObjectNew)
+		},
+		DeleteFunc: func(_ context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			r.enqueueCopyOfTarget(q, e.Object)
+		},
+		GenericFunc: func(_ context.Context, e event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			r.enqueueCopyOfTarget(q, e.Object)
+		},
+	}
+}
+
+// enqueueCopyOfTarget adds the original obj points at to q, unless obj is not a copy or the
+// original is outside the watched namespaces.
+func (r *BookReconciler) enqueueCopyOfTarget(q workqueue.TypedRateLimitingInterface[reconcile.Request], obj client.Object) {
+	book, ok := obj.(*bookstoreexamplecomv1.Book)
+	if !ok || book.Spec.CopyOf == nil {
+		return
+	}
+	if book.Spec.CopyOf.Name == "" || book.Spec.CopyOf.Namespace == "" {
+		return
+	}
+	if !r.Scope.Contains(book.Spec.CopyOf.Namespace) {
+		return
+	}
+	q.Add(reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: book.Spec.CopyOf.Namespace,
+		Name:      book.Spec.CopyOf.Name,
+	}})
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *BookReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&bookstoreexamplecomv1.Book{}).
+		For(&bookstoreexamplecomv1.Book{}, builder.WithPredicates(specOrMetadataChanged())).
 		Watches(
 			&bookstoreexamplecomv1.Book{},
-			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
-				other := obj.(*bookstoreexamplecomv1.Book)
-				if other.Spec.CopyOf == nil {
-					return nil
-				}
-				if other.Spec.CopyOf.Name == "" || other.Spec.CopyOf.Namespace == "" {
-					return nil
-				}
-				return []reconcile.Request{{
-					NamespacedName: types.NamespacedName{
-						Namespace: other.Spec.CopyOf.Namespace,
-						Name:      other.Spec.CopyOf.Name,
-					},
-				}}
-			}),
+			r.enqueueCopyOfTargets(),
+			builder.WithPredicates(copyReferenceChanged()),
 		).
+		Watches(&bookstoreexamplecomv1.BookStore{}, handler.EnqueueRequestsFromMapFunc(r.booksForBookStore),
+			builder.WithPredicates(specOrMetadataChanged())).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.Config.Current().Controllers.Book.MaxConcurrentReconciles,
+		}).
 		Named("book").
-		Complete(r)
+		Complete(tracing.Reconciler("BookReconciler", r))
 }
@@ -18,16 +18,24 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+	"github.com/danieldanieltata/bookstore-operator/internal/sharding"
 )
 
 var _ = Describe("Book Controller", func() {
@@ -51,7 +59,7 @@ var _ = Describe("Book Controller", func() {
 						Name:      resourceName,
 						Namespace: "default",
 					},
-					// TODO(user): Specify other spec details if needed.
+					Spec: bookstoreexamplecomv1.BookSpec{Title: "Test", Price: "10", Genre: "Fiction"},
 				}
 				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
 			}
@@ -68,9 +76,11 @@ var _ = Describe("Book Controller", func() {
 		})
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
+			recorder := events.NewFakeRecorder(100)
 			controllerReconciler := &BookReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
 			}
 
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -80,5 +90,469 @@ var _ = Describe("Book Controller", func() {
 			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
+
+		It("should flag a Book outside any BookStore namespace as Unmanaged", func() {
+			recorder := events.NewFakeRecorder(100)
+			controllerReconciler := &BookReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &bookstoreexamplecomv1.Book{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, bookstoreexamplecomv1.ConditionUnmanaged)).To(BeTrue())
+			Expect(receivedEvents(recorder)).To(ContainElement(HavePrefix("Warning Unmanaged ")))
+		})
+	})
+
+	Context("When many copies of an original are created concurrently", func() {
+		const (
+			originalName = "concurrent-original"
+			copyCount    = 20
+		)
+
+		ctx := context.Background()
+
+		originalKey := types.NamespacedName{Name: originalName, Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating the original Book")
+			original := &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{Name: originalName, Namespace: "default"},
+				Spec:       bookstoreexamplecomv1.BookSpec{Title: "Original", Price: "10", Genre: "Fiction"},
+			}
+			Expect(k8sClient.Create(ctx, original)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("cleaning up the original and its copies")
+			Expect(k8sClient.DeleteAllOf(ctx, &bookstoreexamplecomv1.Book{}, client.InNamespace("default"))).To(Succeed())
+		})
+
+		It("should converge on the number of copies", func() {
+			recorder := events.NewFakeRecorder(100)
+			controllerReconciler := &BookReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+
+			By("creating copies while reconciling the original in parallel")
+			var wg sync.WaitGroup
+			errs := make(chan error, 2*copyCount)
+			for i := range copyCount {
+				wg.Add(2)
+				go func() {
+					defer wg.Done()
+					defer GinkgoRecover()
+					errs <- k8sClient.Create(ctx, &bookstoreexamplecomv1.Book{
+						ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("concurrent-copy-%d", i), Namespace: "default"},
+						Spec: bookstoreexamplecomv1.BookSpec{
+							Title:  fmt.Sprintf("Copy %d", i),
+							CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: "default", Name: originalName},
+						},
+					})
+				}()
+				go func() {
+					defer wg.Done()
+					defer GinkgoRecover()
+					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: originalKey})
+					errs <- err
+				}()
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			By("reconciling once more after the burst")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: originalKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			original := &bookstoreexamplecomv1.Book{}
+			Expect(k8sClient.Get(ctx, originalKey, original)).To(Succeed())
+			Expect(original.Status.ReferenceCount).To(Equal(copyCount))
+			Expect(receivedEvents(recorder)).To(ContainElement(
+				HaveSuffix(fmt.Sprintf("to %d", copyCount)),
+			))
+		})
+	})
+
+	Context("When the operator watches a restricted set of namespaces", func() {
+		const copyName = "copy-of-unwatched"
+
+		ctx := context.Background()
+
+		copyKey := types.NamespacedName{Name: copyName, Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating a copy whose original lives in an unwatched namespace")
+			bookCopy := &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{Name: copyName, Namespace: "default"},
+				Spec: bookstoreexamplecomv1.BookSpec{
+					Title:  "Copy",
+					CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: "elsewhere", Name: "original"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, bookCopy)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.DeleteAllOf(ctx, &bookstoreexamplecomv1.Book{}, client.InNamespace("default"))).To(Succeed())
+		})
+
+		It("should report the copy instead of failing", func() {
+			recorder := events.NewFakeRecorder(100)
+			controllerReconciler := &BookReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+				Scope:    NewWatchScope("default"),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: copyKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &bookstoreexamplecomv1.Book{}
+			Expect(k8sClient.Get(ctx, copyKey, resource)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions,
+				bookstoreexamplecomv1.ConditionOutsideWatchScope)).To(BeTrue())
+			Expect(receivedEvents(recorder)).To(ContainElement(HavePrefix("Warning OriginalNotWatched ")))
+
+			By("skipping a request for the unwatched original")
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "elsewhere", Name: "original"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should clear the condition once the original is watched", func() {
+			recorder := events.NewFakeRecorder(100)
+			controllerReconciler := &BookReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+				Scope:    NewWatchScope("default"),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: copyKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			controllerReconciler.Scope = WatchScope{}
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: copyKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &bookstoreexamplecomv1.Book{}
+			Expect(k8sClient.Get(ctx, copyKey, resource)).To(Succeed())
+			Expect(meta.FindStatusCondition(resource.Status.Conditions,
+				bookstoreexamplecomv1.ConditionOutsideWatchScope)).To(BeNil())
+		})
+	})
+
+	Context("When reconciliation is sharded", func() {
+		const originalName = "sharded-original"
+
+		ctx := context.Background()
+
+		originalKey := types.NamespacedName{Name: originalName, Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating an original and a copy of it")
+			Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{Name: originalName, Namespace: "default"},
+				Spec:       bookstoreexamplecomv1.BookSpec{Title: "Original", Price: "10", Genre: "Fiction"},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{Name: "sharded-copy", Namespace: "default"},
+				Spec: bookstoreexamplecomv1.BookSpec{
+					Title:  "Copy",
+					CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: "default", Name: originalName},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.DeleteAllOf(ctx, &bookstoreexamplecomv1.Book{}, client.InNamespace("default"))).To(Succeed())
+		})
+
+		It("should only let the owning shard count copies", func() {
+			owner := 0
+			if owned, err := (sharding.Sharder{Shard: 1, Count: 2}).OwnsNamespace(ctx, k8sClient, "default"); err != nil {
+				Fail(err.Error())
+			} else if owned {
+				owner = 1
+			}
+
+			reconcileAs := func(shard int) int {
+				controllerReconciler := &BookReconciler{
+					Client:   k8sClient,
+					Scheme:   k8sClient.Scheme(),
+					Recorder: events.NewFakeRecorder(100),
+					Sharder:  sharding.Sharder{Shard: shard, Count: 2},
+				}
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: originalKey})
+				Expect(err).NotTo(HaveOccurred())
+
+				original := &bookstoreexamplecomv1.Book{}
+				Expect(k8sClient.Get(ctx, originalKey, original)).To(Succeed())
+				return original.Status.ReferenceCount
+			}
+
+			By("skipping the original on the other shard")
+			Expect(reconcileAs(1 - owner)).To(BeZero())
+
+			By("counting the copy on the owning shard")
+			Expect(reconcileAs(owner)).To(Equal(1))
+		})
+	})
+
+	Context("When a Book is paused", func() {
+		const originalName = "paused-original"
+
+		ctx := context.Background()
+
+		originalKey := types.NamespacedName{Name: originalName, Namespace: "default"}
+
+		AfterEach(func() {
+			Expect(k8sClient.DeleteAllOf(ctx, &bookstoreexamplecomv1.Book{}, client.InNamespace("default"))).To(Succeed())
+		})
+
+		It("should leave referenceCount alone until it is unpaused", func() {
+			recorder := events.NewFakeRecorder(100)
+			controllerReconciler := &BookReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+
+			By("creating a paused original and a copy of it")
+			original := &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        originalName,
+					Namespace:   "default",
+					Annotations: map[string]string{bookstoreexamplecomv1.PausedAnnotation: "true"},
+				},
+				Spec: bookstoreexamplecomv1.BookSpec{Title: "Original", Price: "10", Genre: "Fiction"},
+			}
+			Expect(k8sClient.Create(ctx, original)).To(Succeed())
+			Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{Name: "paused-copy", Namespace: "default"},
+				Spec: bookstoreexamplecomv1.BookSpec{
+					Title:  "Copy",
+					CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: "default", Name: originalName},
+				},
+			})).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: originalKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, originalKey, original)).To(Succeed())
+			Expect(original.Status.ReferenceCount).To(BeZero())
+			cond := meta.FindStatusCondition(original.Status.Conditions, bookstoreexamplecomv1.ConditionPaused)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Reason).To(Equal("Annotated"))
+			Expect(meta.FindStatusCondition(original.Status.Conditions, bookstoreexamplecomv1.ConditionUnmanaged)).To(BeNil())
+
+			By("removing the annotation")
+			original.Annotations = nil
+			Expect(k8sClient.Update(ctx, original)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: originalKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, originalKey, original)).To(Succeed())
+			Expect(original.Status.ReferenceCount).To(Equal(1))
+			Expect(meta.FindStatusCondition(original.Status.Conditions, bookstoreexamplecomv1.ConditionPaused)).To(BeNil())
+			Expect(receivedEvents(recorder)).To(ContainElements(HavePrefix("Normal Paused "), HavePrefix("Normal Resumed ")))
+		})
+	})
+
+	Context("When filtering events", func() {
+		copyOf := func(name string, generation int64) *bookstoreexamplecomv1.Book {
+			book := &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{Name: "copy", Namespace: "default", Generation: generation},
+			}
+			if name != "" {
+				book.Spec.CopyOf = &bookstoreexamplecomv1.CopyOf{Namespace: "default", Name: name}
+			}
+			return book
+		}
+
+		It("should drop status-only updates", func() {
+			old := copyOf("", 1)
+			updated := old.DeepCopy()
+			updated.Status.ReferenceCount = 3
+			Expect(specOrMetadataChanged().Update(event.UpdateEvent{ObjectOld: old, ObjectNew: updated})).To(BeFalse())
+
+			updated.Annotations = map[string]string{bookstoreexamplecomv1.PausedAnnotation: "true"}
+			Expect(specOrMetadataChanged().Update(event.UpdateEvent{ObjectOld: old, ObjectNew: updated})).To(BeTrue())
+		})
+
+		It("should fire the copyOf watch when a copy's reference changes", func() {
+			predicate := copyReferenceChanged()
+
+			Expect(predicate.Create(event.CreateEvent{Object: copyOf("original", 1)})).To(BeTrue())
+			Expect(predicate.Create(event.CreateEvent{Object: copyOf("", 1)})).To(BeFalse())
+			Expect(predicate.Delete(event.DeleteEvent{Object: copyOf("original", 1)})).To(BeTrue())
+
+			By("ignoring a status update to a copy")
+			statusOnly := copyOf("original", 1)
+			statusOnly.Status.ReferenceCount = 1
+			Expect(predicate.Update(event.UpdateEvent{ObjectOld: copyOf("original", 1), ObjectNew: statusOnly})).To(BeFalse())
+
+			By("firing when the copy is repointed or turned into an original")
+			Expect(predicate.Update(event.UpdateEvent{ObjectOld: copyOf("original", 1), ObjectNew: copyOf("other", 2)})).To(BeTrue())
+			Expect(predicate.Update(event.UpdateEvent{ObjectOld: copyOf("original", 1), ObjectNew: copyOf("", 2)})).To(BeTrue())
+		})
+
+		It("should record the reconciled generation", func() {
+			key := types.NamespacedName{Name: "observed-original", Namespace: "default"}
+			Expect(k8sClient.Create(context.Background(), &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				Spec:       bookstoreexamplecomv1.BookSpec{Title: "Original", Price: "10", Genre: "Fiction"},
+			})).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.DeleteAllOf(context.Background(), &bookstoreexamplecomv1.Book{},
+					client.InNamespace("default"))).To(Succeed())
+			})
+
+			controllerReconciler := &BookReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: events.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+
+			book := &bookstoreexamplecomv1.Book{}
+			Expect(k8sClient.Get(context.Background(), key, book)).To(Succeed())
+			Expect(book.Status.ObservedGeneration).To(Equal(book.Generation))
+		})
+	})
+
+	Context("When a copy is repointed to another original", func() {
+		ctx := context.Background()
+
+		originalA := types.NamespacedName{Name: "repoint-original-a", Namespace: "default"}
+		originalB := types.NamespacedName{Name: "repoint-original-b", Namespace: "default"}
+		copyKey := types.NamespacedName{Name: "repoint-copy", Namespace: "default"}
+
+		// drain returns everything the handler put on the queue.
+		drain := func(q workqueue.TypedRateLimitingInterface[reconcile.Request]) []reconcile.Request {
+			var requests []reconcile.Request
+			for q.Len() > 0 {
+				req, _ := q.Get()
+				q.Done(req)
+				requests = append(requests, req)
+			}
+			return requests
+		}
+
+		referenceCount := func(key types.NamespacedName) int {
+			book := &bookstoreexamplecomv1.Book{}
+			Expect(k8sClient.Get(ctx, key, book)).To(Succeed())
+			return book.Status.ReferenceCount
+		}
+
+		BeforeEach(func() {
+			for _, key := range []types.NamespacedName{originalA, originalB} {
+				Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.Book{
+					ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+					Spec:       bookstoreexamplecomv1.BookSpec{Title: key.Name, Price: "10", Genre: "Fiction"},
+				})).To(Succeed())
+			}
+			Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{Name: copyKey.Name, Namespace: copyKey.Namespace},
+				Spec: bookstoreexamplecomv1.BookSpec{
+					Title:  "Copy",
+					CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: originalA.Namespace, Name: originalA.Name},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.DeleteAllOf(ctx, &bookstoreexamplecomv1.Book{}, client.InNamespace("default"))).To(Succeed())
+		})
+
+		It("should recount both the old and the new original", func() {
+			controllerReconciler := &BookReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: events.NewFakeRecorder(100),
+			}
+			handler := controllerReconciler.enqueueCopyOfTargets()
+			queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			DeferCleanup(queue.ShutDown)
+
+			reconcileAll := func(requests []reconcile.Request) {
+				for _, req := range requests {
+					_, err := controllerReconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+				}
+			}
+
+			By("counting the copy toward A")
+			copyBook := &bookstoreexamplecomv1.Book{}
+			Expect(k8sClient.Get(ctx, copyKey, copyBook)).To(Succeed())
+			handler.Create(ctx, event.CreateEvent{Object: copyBook}, queue)
+			reconcileAll(drain(queue))
+			Expect(referenceCount(originalA)).To(Equal(1))
+
+			By("repointing the copy from A to B")
+			old := copyBook.DeepCopy()
+			copyBook.Spec.CopyOf.Name = originalB.Name
+			Expect(k8sClient.Update(ctx, copyBook)).To(Succeed())
+			handler.Update(ctx, event.UpdateEvent{ObjectOld: old, ObjectNew: copyBook}, queue)
+			requests := drain(queue)
+			Expect(requests).To(ConsistOf(
+				reconcile.Request{NamespacedName: originalA},
+				reconcile.Request{NamespacedName: originalB},
+			))
+			reconcileAll(requests)
+			Expect(referenceCount(originalA)).To(BeZero())
+			Expect(referenceCount(originalB)).To(Equal(1))
+
+			By("deleting the copy")
+			Expect(k8sClient.Delete(ctx, copyBook)).To(Succeed())
+			handler.Delete(ctx, event.DeleteEvent{Object: copyBook}, queue)
+			requests = drain(queue)
+			Expect(requests).To(ConsistOf(reconcile.Request{NamespacedName: originalB}))
+			reconcileAll(requests)
+			Expect(referenceCount(originalB)).To(BeZero())
+		})
+
+		It("should recount the old original when a copy becomes an original", func() {
+			controllerReconciler := &BookReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: events.NewFakeRecorder(100),
+			}
+			queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			DeferCleanup(queue.ShutDown)
+
+			copyBook := &bookstoreexamplecomv1.Book{}
+			Expect(k8sClient.Get(ctx, copyKey, copyBook)).To(Succeed())
+			old := copyBook.DeepCopy()
+			copyBook.Spec.CopyOf = nil
+			copyBook.Spec.Price = "12"
+			copyBook.Spec.Genre = "Fiction"
+
+			controllerReconciler.enqueueCopyOfTargets().Update(ctx, event.UpdateEvent{ObjectOld: old, ObjectNew: copyBook}, queue)
+			Expect(drain(queue)).To(ConsistOf(reconcile.Request{NamespacedName: originalA}))
+		})
+
+		It("should not enqueue originals outside the watched namespaces", func() {
+			controllerReconciler := &BookReconciler{Scope: NewWatchScope("other")}
+			queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			DeferCleanup(queue.ShutDown)
+
+			copyBook := &bookstoreexamplecomv1.Book{}
+			Expect(k8sClient.Get(ctx, copyKey, copyBook)).To(Succeed())
+			controllerReconciler.enqueueCopyOfTargets().Create(ctx, event.CreateEvent{Object: copyBook}, queue)
+			Expect(queue.Len()).To(BeZero())
+		})
 	})
 })
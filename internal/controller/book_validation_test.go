@@ -0,0 +1,108 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+)
+
+// These specs run against the API server without the webhook, so they only see the CEL rules in
+// the Book CRD.
+var _ = Describe("Book CRD validation", func() {
+	ctx := context.Background()
+
+	book := func(name string, spec bookstoreexamplecomv1.BookSpec) *bookstoreexamplecomv1.Book {
+		return &bookstoreexamplecomv1.Book{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       spec,
+		}
+	}
+	copyOf := &bookstoreexamplecomv1.CopyOf{Namespace: "default", Name: "cel-original"}
+
+	expectRejected := func(err error, message string) {
+		GinkgoHelper()
+		Expect(errors.IsInvalid(err)).To(BeTrue(), "expected an Invalid error, got %v", err)
+		Expect(err.Error()).To(ContainSubstring(message))
+	}
+
+	cleanup := func(obj client.Object) {
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, obj))).To(Succeed())
+		})
+	}
+
+	It("should require title, price and genre on an original", func() {
+		err := k8sClient.Create(ctx, book("cel-incomplete", bookstoreexamplecomv1.BookSpec{Title: "Original"}))
+		expectRejected(err, "title, price and genre are required for a Book without copyOf")
+	})
+
+	It("should require a copy to override at least one field", func() {
+		err := k8sClient.Create(ctx, book("cel-empty-copy", bookstoreexamplecomv1.BookSpec{CopyOf: copyOf}))
+		expectRejected(err, "must override at least one of title, price, or genre")
+	})
+
+	It("should require both halves of copyOf", func() {
+		err := k8sClient.Create(ctx, book("cel-half-copy", bookstoreexamplecomv1.BookSpec{
+			Title:  "Copy",
+			CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: "default"},
+		}))
+		expectRejected(err, "copyOf needs both namespace and name")
+	})
+
+	It("should admit valid originals and copies", func() {
+		original := book("cel-original", bookstoreexamplecomv1.BookSpec{Title: "Original", Price: "10", Genre: "Fiction"})
+		Expect(k8sClient.Create(ctx, original)).To(Succeed())
+		cleanup(original)
+
+		bookCopy := book("cel-copy", bookstoreexamplecomv1.BookSpec{Price: "12", CopyOf: copyOf})
+		Expect(k8sClient.Create(ctx, bookCopy)).To(Succeed())
+		cleanup(bookCopy)
+	})
+
+	Context("When a Book is counted as an original", func() {
+		var original *bookstoreexamplecomv1.Book
+
+		BeforeEach(func() {
+			original = book("cel-counted", bookstoreexamplecomv1.BookSpec{Title: "Original", Price: "10", Genre: "Fiction"})
+			Expect(k8sClient.Create(ctx, original)).To(Succeed())
+			cleanup(original)
+			original.Status.ReferenceCount = 1
+			Expect(k8sClient.Status().Update(ctx, original)).To(Succeed())
+		})
+
+		It("should not let it become a copy", func() {
+			original.Spec.CopyOf = copyOf
+			expectRejected(k8sClient.Update(ctx, original), "cannot gain or lose spec.copyOf")
+		})
+
+		It("should let it become a copy once nothing counts it", func() {
+			original.Status.ReferenceCount = 0
+			Expect(k8sClient.Status().Update(ctx, original)).To(Succeed())
+
+			original.Spec.CopyOf = copyOf
+			Expect(k8sClient.Update(ctx, original)).To(Succeed())
+		})
+	})
+})
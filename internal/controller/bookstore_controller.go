@@ -18,16 +18,34 @@ package controller
 
 import (
 	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+	"github.com/danieldanieltata/bookstore-operator/internal/config"
+	"github.com/danieldanieltata/bookstore-operator/internal/metrics"
+	"github.com/danieldanieltata/bookstore-operator/internal/sharding"
+	"github.com/danieldanieltata/bookstore-operator/internal/stores"
+	"github.com/danieldanieltata/bookstore-operator/internal/tracing"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,16 +53,59 @@ import (
 
 const bookStoreFinalizer = "bookstore.example.com/finalizer"
 
+// cleanupBatchDelay spaces out the passes of a finalizer cleanup that is split into batches.
+const cleanupBatchDelay = time.Second
+
+// cleanupStartedAnnotation records when the finalizer cleanup of a BookStore began, so a cleanup split
+// into batches is timed, and announced, once.
+const cleanupStartedAnnotation = "bookstore.example.com/cleanup-started"
+
+// pausedBooksDelay is how often a finalizer cleanup held back by paused Books checks again. Unpausing
+// a Book does not trigger the BookStore, so this bounds how long the cleanup lags behind.
+const pausedBooksDelay = 30 * time.Second
+
+// Event reasons recorded on BookStores.
+const (
+	reasonNamespaceCreated     = "NamespaceCreated"
+	reasonNamespaceRepaired    = "NamespaceRepaired"
+	reasonNamespaceTerminating = "NamespaceTerminating"
+	reasonNamespaceDeleted     = "NamespaceDeleted"
+	reasonNamespaceFailed      = "NamespaceFailed"
+	reasonCleanupStarted       = "CleanupStarted"
+	reasonBooksDeleted         = "BooksDeleted"
+	reasonRemoteCopyDeleted    = "RemoteCopyDeleted"
+	reasonCleanupFailed        = "CleanupFailed"
+	reasonCleanupCompleted     = "CleanupCompleted"
+	reasonBooksNotWatched      = "BooksNotWatched"
+	reasonCleanupBlocked       = "CleanupBlocked"
+	reasonPaused               = "Paused"
+	reasonResumed              = "Resumed"
+)
+
 // BookStoreReconciler reconciles a BookStore object
 type BookStoreReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
+	Config   *config.Watcher
+	// Scope is the set of namespaces the manager cache covers. The zero value is the whole cluster.
+	Scope WatchScope
+	// Sharder selects the BookStores this replica reconciles. The zero value reconciles all of them.
+	Sharder sharding.Sharder
+	// DryRun is set when Client only simulates writes. A finalizer cleanup then runs as a single
+	// pass, since its deletes and its progress are never stored.
+	DryRun bool
+
+	// simulatedCleanups holds the UIDs of the BookStores whose cleanup a dry run already simulated.
+	simulatedCleanups sync.Map
 }
 
 // +kubebuilder:rbac:groups=bookstore.example.com,resources=bookstores,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=bookstore.example.com,resources=bookstores/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=bookstore.example.com,resources=bookstores/finalizers,verbs=update
 // +kubebuilder:rbac:groups=bookstore.example.com,resources=books,verbs=list;delete
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -60,6 +121,8 @@ func (r *BookStoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	log.Info("Reconciling BookStore", "request", req)
 
+	cfg := r.Config.Current()
+
 	// Fetch the BookStore, if not found, it was deleted, do not create or update anything.
 	bookstore := &bookstoreexamplecomv1.BookStore{}
 	err := r.Get(ctx, req.NamespacedName, bookstore)
@@ -70,107 +133,469 @@ func (r *BookStoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
+	// Every replica sees every BookStore, the shard that owns it is the only one acting on it.
+	if !r.Sharder.OwnsStore(bookstore) {
+		log.V(1).Info("BookStore belongs to another shard, skipping reconciliation", "shard", r.Sharder.ShardFor(bookstore))
+		return ctrl.Result{}, nil
+	}
+
+	// A paused store is left exactly as it is, finalizer cleanup included, until the annotation is removed.
+	if bookstoreexamplecomv1.IsPaused(bookstore) {
+		log.Info("BookStore is paused, skipping reconciliation", "bookstore", req.NamespacedName)
+		return ctrl.Result{}, r.setPaused(ctx, bookstore, true)
+	}
+	if err := r.setPaused(ctx, bookstore, false); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Handle deletion: delete all related Books then remove finalizer.
 	if bookstore.DeletionTimestamp != nil {
 		log.Info("BookStore is being deleted, running finalizer cleanup", "bookstore", req.NamespacedName, "namespace", bookstore.Namespace)
 		if controllerutil.ContainsFinalizer(bookstore, bookStoreFinalizer) {
-			if err := r.deleteBooksForBookStore(ctx, bookstore); err != nil {
+			if _, simulated := r.simulatedCleanups.Load(bookstore.UID); simulated {
+				log.V(1).Info("Dry run: cleanup already simulated", "bookstore", req.NamespacedName)
+				return ctrl.Result{}, nil
+			}
+			paused, err := r.pausedBooksForBookStore(ctx, bookstore, cfg.Cleanup)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if len(paused) > 0 {
+				log.Info("Paused Books hold back the cleanup", "bookstore", req.NamespacedName, "books", paused)
+				r.Recorder.Eventf(bookstore, nil, corev1.EventTypeWarning, reasonCleanupBlocked, "Cleanup",
+					"Waiting for paused Books to be unpaused: %s", strings.Join(paused, ", "))
+				return ctrl.Result{RequeueAfter: pausedBooksDelay}, nil
+			}
+			cleanupStart, err := r.startCleanup(ctx, bookstore)
+			if err != nil {
 				return ctrl.Result{}, err
 			}
+			// A batch is never stored in a dry run, so a batched cleanup would start over forever.
+			cleanup := cfg.Cleanup
+			if r.DryRun {
+				cleanup.BatchSize = 0
+			}
+			done, err := r.deleteBooksForBookStore(ctx, bookstore, cleanup)
+			if err != nil {
+				r.Recorder.Eventf(bookstore, nil, corev1.EventTypeWarning, reasonCleanupFailed, "Cleanup",
+					"Failed to delete Books: %v", err)
+				return ctrl.Result{}, err
+			}
+			if !done {
+				log.Info("Cleanup batch done, continuing on requeue", "bookstore", req.NamespacedName)
+				return ctrl.Result{RequeueAfter: cleanupBatchDelay}, nil
+			}
+			metrics.CleanupDuration.Observe(time.Since(cleanupStart).Seconds())
 			controllerutil.RemoveFinalizer(bookstore, bookStoreFinalizer)
 			if err := r.Update(ctx, bookstore); err != nil {
 				log.Error(err, "Failed to remove finalizer", "bookstore", req.NamespacedName)
+				r.Recorder.Eventf(bookstore, nil, corev1.EventTypeWarning, reasonCleanupFailed, "Cleanup",
+					"Failed to remove finalizer: %v", err)
 				return ctrl.Result{}, err
 			}
+			r.Recorder.Eventf(bookstore, nil, corev1.EventTypeNormal, reasonCleanupCompleted, "Cleanup",
+				"Finalizer removed, BookStore will be deleted")
 			log.Info("Finalizer removed, BookStore will be deleted", "bookstore", req.NamespacedName)
+			if r.DryRun {
+				r.simulatedCleanups.Store(bookstore.UID, struct{}{})
+			}
 		}
 		return ctrl.Result{}, nil
 	}
 
-	// Ensure finalizer is set so we can clean up on delete.
+	// Ensure finalizer is set so we can clean up on delete. Update refreshes bookstore, so the
+	// reconcile carries on instead of waiting for a requeue, which a --dry-run manager would never
+	// get past since the finalizer is not stored.
 	if !controllerutil.ContainsFinalizer(bookstore, bookStoreFinalizer) {
 		controllerutil.AddFinalizer(bookstore, bookStoreFinalizer)
 		if err := r.Update(ctx, bookstore); err != nil {
 			return ctrl.Result{}, err
 		}
-		return ctrl.Result{Requeue: true}, nil
 	}
 
-	// Create namespace with the name of the BookStore if it does not exist.
-	namespace := &corev1.Namespace{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: req.Name,
-		},
+	// Create namespace with the name of the BookStore if it does not exist, and repair it if it drifted.
+	if err := r.ensureNamespace(ctx, bookstore); err != nil {
+		return ctrl.Result{}, err
 	}
 
-	foundNamespace := &corev1.Namespace{}
-	err = r.Get(ctx, types.NamespacedName{Name: req.Name}, foundNamespace)
-	if err != nil && errors.IsNotFound(err) {
-		if err = r.Create(ctx, namespace); err != nil {
+	copyHolders, err := stores.CopyHolders(ctx, r.Client, bookstore)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if bookstore.Status.ObservedGeneration != bookstore.Generation ||
+		!slices.Equal(bookstore.Status.CopyHolders, copyHolders) {
+		patch := client.MergeFrom(bookstore.DeepCopy())
+		bookstore.Status.ObservedGeneration = bookstore.Generation
+		bookstore.Status.CopyHolders = copyHolders
+		if err := r.Status().Patch(ctx, bookstore, patch, client.FieldOwner(fieldManager)); err != nil {
 			return ctrl.Result{}, err
 		}
+	}
+
+	return ctrl.Result{RequeueAfter: cfg.Controllers.BookStore.RequeueAfter()}, nil
+}
+
+// startCleanup returns when the finalizer cleanup of bookstore began. On the first batch it records
+// the time in cleanupStartedAnnotation and emits the CleanupStarted Event.
+func (r *BookStoreReconciler) startCleanup(ctx context.Context, bookstore *bookstoreexamplecomv1.BookStore) (time.Time, error) {
+	if started, err := time.Parse(time.RFC3339Nano, bookstore.GetAnnotations()[cleanupStartedAnnotation]); err == nil {
+		return started, nil
+	}
 
-		bookstore.SetOwnerReferences([]metav1.OwnerReference{
-			{
-				APIVersion: "v1",
-				Kind:       "Namespace",
-				Name:       namespace.Name,
-				UID:        namespace.UID,
+	started := time.Now()
+	patch := client.MergeFrom(bookstore.DeepCopy())
+	metav1.SetMetaDataAnnotation(&bookstore.ObjectMeta, cleanupStartedAnnotation, started.UTC().Format(time.RFC3339Nano))
+	if err := r.Patch(ctx, bookstore, patch, client.FieldOwner(fieldManager)); err != nil {
+		return time.Time{}, err
+	}
+	r.Recorder.Eventf(bookstore, nil, corev1.EventTypeNormal, reasonCleanupStarted, "Cleanup",
+		"Deleting Books in namespace %s and copies of them in other stores", bookstore.Name)
+	return started, nil
+}
+
+// ensureNamespace creates the store namespace when it is missing and restores its labels when
+// someone changed them, then records the outcome in the NamespaceReady condition. The namespace owns
+// the store, so once it has been created or adopted, deleting it is not repaired: the garbage
+// collector deletes the store with it.
+func (r *BookStoreReconciler) ensureNamespace(ctx context.Context, bookstore *bookstoreexamplecomv1.BookStore) error {
+	log := logf.FromContext(ctx)
+
+	foundNamespace := &corev1.Namespace{}
+	err := r.Get(ctx, types.NamespacedName{Name: bookstore.Name}, foundNamespace)
+	if err != nil && errors.IsNotFound(err) && ownedByNamespace(bookstore) {
+		log.Info("Namespace was deleted, the BookStore is deleted with it", "namespace", bookstore.Name)
+		r.Recorder.Eventf(bookstore, nil, corev1.EventTypeWarning, reasonNamespaceDeleted, "Wait",
+			"Namespace %s was deleted, the BookStore is deleted with it", bookstore.Name)
+		return r.setNamespaceReady(ctx, bookstore, metav1.ConditionFalse, "Deleted", "namespace was deleted")
+	} else if err != nil && errors.IsNotFound(err) {
+		namespace := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   bookstore.Name,
+				Labels: namespaceLabels(bookstore),
 			},
-		})
-		err = r.Update(ctx, bookstore)
-		if err != nil {
-			return ctrl.Result{}, err
+		}
+		if err = r.Create(ctx, namespace); err != nil {
+			r.Recorder.Eventf(bookstore, nil, corev1.EventTypeWarning, reasonNamespaceFailed, "Create",
+				"Failed to create namespace %s: %v", namespace.Name, err)
+			_ = r.setNamespaceReady(ctx, bookstore, metav1.ConditionFalse, "CreateFailed", err.Error())
+			return err
+		}
+
+		if err := r.setNamespaceOwner(ctx, bookstore, namespace); err != nil {
+			return err
 		}
 		log.Info("Namespace created", "namespace", namespace.Name)
+		r.Recorder.Eventf(bookstore, namespace, corev1.EventTypeNormal, reasonNamespaceCreated, "Create",
+			"Created namespace %s", namespace.Name)
+		return r.setNamespaceReady(ctx, bookstore, metav1.ConditionTrue, "Created", "namespace created")
 	} else if err != nil {
-		return ctrl.Result{}, err
+		return err
+	}
+
+	// A terminating namespace cannot be repaired, and the store goes with it once it is gone.
+	if foundNamespace.DeletionTimestamp != nil {
+		log.Info("Namespace is terminating, the BookStore is deleted with it", "namespace", foundNamespace.Name)
+		r.Recorder.Eventf(bookstore, foundNamespace, corev1.EventTypeWarning, reasonNamespaceTerminating, "Wait",
+			"Namespace %s is being deleted, the BookStore is deleted with it", foundNamespace.Name)
+		return r.setNamespaceReady(ctx, bookstore, metav1.ConditionFalse, "Terminating", "namespace is being deleted")
+	}
+
+	// An adopted namespace owns the store from now on, like one the store created.
+	if !ownedByNamespace(bookstore) {
+		if err := r.setNamespaceOwner(ctx, bookstore, foundNamespace); err != nil {
+			return err
+		}
+	}
+
+	if hasLabels(foundNamespace, namespaceLabels(bookstore)) {
+		return r.setNamespaceReady(ctx, bookstore, metav1.ConditionTrue, "Ready", "namespace exists")
 	}
 
-	return ctrl.Result{}, nil
+	patch := client.MergeFrom(foundNamespace.DeepCopy())
+	if foundNamespace.Labels == nil {
+		foundNamespace.Labels = map[string]string{}
+	}
+	maps.Copy(foundNamespace.Labels, namespaceLabels(bookstore))
+	if err := r.Patch(ctx, foundNamespace, patch, client.FieldOwner(fieldManager)); err != nil {
+		r.Recorder.Eventf(bookstore, foundNamespace, corev1.EventTypeWarning, reasonNamespaceFailed, "Update",
+			"Failed to restore labels on namespace %s: %v", foundNamespace.Name, err)
+		_ = r.setNamespaceReady(ctx, bookstore, metav1.ConditionFalse, "RepairFailed", err.Error())
+		return err
+	}
+	log.Info("Namespace labels repaired", "namespace", foundNamespace.Name)
+	r.Recorder.Eventf(bookstore, foundNamespace, corev1.EventTypeNormal, reasonNamespaceRepaired, "Update",
+		"Restored labels on namespace %s", foundNamespace.Name)
+	return r.setNamespaceReady(ctx, bookstore, metav1.ConditionTrue, "Repaired", "namespace labels restored")
+}
+
+// setNamespaceOwner makes namespace the owner of bookstore, so deleting the namespace deletes the store.
+func (r *BookStoreReconciler) setNamespaceOwner(ctx context.Context, bookstore *bookstoreexamplecomv1.BookStore,
+	namespace *corev1.Namespace) error {
+	bookstore.SetOwnerReferences(append(bookstore.GetOwnerReferences(), metav1.OwnerReference{
+		APIVersion: "v1",
+		Kind:       "Namespace",
+		Name:       namespace.Name,
+		UID:        namespace.UID,
+	}))
+	return r.Update(ctx, bookstore)
 }
 
-func (r *BookStoreReconciler) deleteBooksForBookStore(ctx context.Context, bookstore *bookstoreexamplecomv1.BookStore) error {
+// ownedByNamespace reports whether bookstore is owned by the namespace of the same name.
+func ownedByNamespace(bookstore *bookstoreexamplecomv1.BookStore) bool {
+	for _, owner := range bookstore.GetOwnerReferences() {
+		if owner.APIVersion == "v1" && owner.Kind == "Namespace" && owner.Name == bookstore.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// setNamespaceReady patches the NamespaceReady condition when it changed. Errors from the
+// failure paths are intentionally dropped by callers, the original error is the one returned.
+func (r *BookStoreReconciler) setNamespaceReady(ctx context.Context, bookstore *bookstoreexamplecomv1.BookStore,
+	status metav1.ConditionStatus, reason, message string) error {
+	patch := client.MergeFrom(bookstore.DeepCopy())
+	changed := meta.SetStatusCondition(&bookstore.Status.Conditions, metav1.Condition{
+		Type:               bookstoreexamplecomv1.ConditionNamespaceReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: bookstore.Generation,
+	})
+	if !changed {
+		return nil
+	}
+	return r.Status().Patch(ctx, bookstore, patch, client.FieldOwner(fieldManager))
+}
+
+// setPaused adds or removes the Paused condition and records an Event when it flips.
+func (r *BookStoreReconciler) setPaused(ctx context.Context, bookstore *bookstoreexamplecomv1.BookStore, paused bool) error {
+	patch := client.MergeFrom(bookstore.DeepCopy())
+	var changed bool
+	if paused {
+		changed = meta.SetStatusCondition(&bookstore.Status.Conditions, metav1.Condition{
+			Type:               bookstoreexamplecomv1.ConditionPaused,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Annotated",
+			Message:            "reconciliation is paused by the " + bookstoreexamplecomv1.PausedAnnotation + " annotation",
+			ObservedGeneration: bookstore.Generation,
+		})
+	} else {
+		changed = meta.RemoveStatusCondition(&bookstore.Status.Conditions, bookstoreexamplecomv1.ConditionPaused)
+	}
+	if !changed {
+		return nil
+	}
+	if err := r.Status().Patch(ctx, bookstore, patch, client.FieldOwner(fieldManager)); err != nil {
+		return err
+	}
+	if paused {
+		r.Recorder.Eventf(bookstore, nil, corev1.EventTypeNormal, reasonPaused, "Pause",
+			"Reconciliation paused by the %s annotation", bookstoreexamplecomv1.PausedAnnotation)
+	} else {
+		r.Recorder.Eventf(bookstore, nil, corev1.EventTypeNormal, reasonResumed, "Resume", "Reconciliation resumed")
+	}
+	return nil
+}
+
+// pausedBooksForBookStore returns the namespace/name of every paused Book the finalizer cleanup
+// would delete. The cleanup waits for them rather than deleting Books someone is working on.
+func (r *BookStoreReconciler) pausedBooksForBookStore(ctx context.Context, bookstore *bookstoreexamplecomv1.BookStore,
+	cleanup config.CleanupConfig) ([]string, error) {
+	var books bookstoreexamplecomv1.BookList
+	if r.Scope.Contains(bookstore.Name) {
+		if err := r.List(ctx, &books, client.InNamespace(bookstore.Name)); err != nil {
+			return nil, err
+		}
+	}
+	if cleanup.DeletionPolicy != config.DeletionPolicyOrphan {
+		copies, err := stores.Copies(ctx, r.Client, bookstore.Name)
+		if err != nil {
+			return nil, err
+		}
+		books.Items = append(books.Items, copies...)
+	}
+
+	var paused []string
+	for _, book := range books.Items {
+		if bookstoreexamplecomv1.IsPaused(&book) {
+			paused = append(paused, book.Namespace+"/"+book.Name)
+		}
+	}
+	slices.Sort(paused)
+	return slices.Compact(paused), nil
+}
+
+// namespaceLabels returns the labels every store namespace must carry.
+func namespaceLabels(bookstore *bookstoreexamplecomv1.BookStore) map[string]string {
+	return map[string]string{
+		bookstoreexamplecomv1.BookStoreLabel: bookstore.Name,
+		"app.kubernetes.io/managed-by":       fieldManager,
+	}
+}
+
+func hasLabels(obj client.Object, want map[string]string) bool {
+	labels := obj.GetLabels()
+	for k, v := range want {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// deleteBooksForBookStore deletes the Books in the store namespace and, unless the deletion policy
+// orphans them, the copies of those Books in other stores. It stops after cleanup.BatchSize
+// deletions and reports whether it got through everything.
+func (r *BookStoreReconciler) deleteBooksForBookStore(ctx context.Context, bookstore *bookstoreexamplecomv1.BookStore,
+	cleanup config.CleanupConfig) (bool, error) {
 	log := logf.FromContext(ctx)
 
 	// bookstore namespace is the same as the bookstore name, i wasn't sure if we wanted to put the bookstore in the created namespace or not.
 	bookstoreNS := bookstore.Name
 
+	deleted := 0
+	batchFull := func() bool {
+		return cleanup.BatchSize > 0 && deleted >= cleanup.BatchSize
+	}
+
+	// Listing a namespace the cache does not cover fails, so a store outside the watch scope is
+	// reported and its Books are left for whoever watches that namespace.
 	var inNSList bookstoreexamplecomv1.BookList
-	if err := r.List(ctx, &inNSList, client.InNamespace(bookstoreNS)); err != nil {
-		return err
+	if r.Scope.Contains(bookstoreNS) {
+		if err := r.List(ctx, &inNSList, client.InNamespace(bookstoreNS)); err != nil {
+			return false, err
+		}
+	} else {
+		log.Info("Store namespace is outside the watched namespaces, not deleting its Books", "namespace", bookstoreNS)
+		r.Recorder.Eventf(bookstore, nil, corev1.EventTypeWarning, reasonBooksNotWatched, "Cleanup",
+			"Namespace %s is outside the watched namespaces, its Books were not deleted", bookstoreNS)
 	}
 	for i := range inNSList.Items {
+		if batchFull() {
+			break
+		}
 		b := &inNSList.Items[i]
-		if err := r.Delete(ctx, b); err != nil && !errors.IsNotFound(err) {
-			return err
+		if err := r.Delete(ctx, b); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return false, err
 		}
+		deleted++
+		metrics.DeletedBooks.WithLabelValues(metrics.DeletedInStore).Inc()
 		log.Info("Deleted Book in bookstore namespace", "book", b.Name, "namespace", b.Namespace)
 	}
+	if deleted > 0 {
+		r.Recorder.Eventf(bookstore, nil, corev1.EventTypeNormal, reasonBooksDeleted, "Cleanup",
+			"Deleted %d Books in namespace %s", deleted, bookstoreNS)
+	}
+	if batchFull() {
+		return false, nil
+	}
 
-	var allBooks bookstoreexamplecomv1.BookList
-	if err := r.List(ctx, &allBooks); err != nil {
-		return err
+	if cleanup.DeletionPolicy == config.DeletionPolicyOrphan {
+		log.Info("Deletion policy orphans copies in other stores, leaving them in place", "bookstore", bookstore.Name)
+		return true, nil
+	}
+
+	copies, err := stores.Copies(ctx, r.Client, bookstoreNS)
+	if err != nil {
+		return false, err
 	}
-	for i := range allBooks.Items {
-		b := &allBooks.Items[i]
+	for i := range copies {
+		b := &copies[i]
 
-		if b.Spec.CopyOf == nil || b.Spec.CopyOf.Namespace != bookstoreNS {
-			continue
+		if batchFull() {
+			return false, nil
 		}
-		if err := r.Delete(ctx, b); err != nil && !errors.IsNotFound(err) {
-			return err
+		if err := r.Delete(ctx, b); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return false, err
 		}
+		deleted++
+		metrics.DeletedBooks.WithLabelValues(metrics.DeletedRemoteCopy).Inc()
 		log.Info("Deleted copy Book", "book", b.Name, "namespace", b.Namespace, "copyOf", b.Spec.CopyOf)
+		r.Recorder.Eventf(bookstore, b, corev1.EventTypeNormal, reasonRemoteCopyDeleted, "Cleanup",
+			"Deleted Book %s/%s, a copy of %s", b.Namespace, b.Name, b.Spec.CopyOf.Name)
 	}
 
-	return nil
+	// The list above only covers the watched namespaces, so copies elsewhere are not known here.
+	if r.Scope.Restricted() {
+		log.Info("Copies outside the watched namespaces were not checked", "watchNamespaces", r.Scope.Namespaces())
+		r.Recorder.Eventf(bookstore, nil, corev1.EventTypeWarning, reasonBooksNotWatched, "Cleanup",
+			"Copies in namespaces outside %v were not checked and may still point at %s",
+			r.Scope.Namespaces(), bookstoreNS)
+	}
+
+	return true, nil
+}
+
+// enqueueSourceStores enqueues the store whose Book a copy points at, so its copyHolders stays
+// current. On update the store the copy used to point at is enqueued too.
+func (r *BookStoreReconciler) enqueueSourceStores() handler.EventHandler {
+	return handler.Funcs{
+		CreateFunc: func(ctx context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			r.enqueueSourceStore(ctx, q, e.Object)
+		},
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			r.enqueueSourceStore(ctx, q, e.ObjectOld)
+			r.enqueueSourceStore(ctx, q, e.ObjectNew)
+		},
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			r.enqueueSourceStore(ctx, q, e.Object)
+		},
+		GenericFunc: func(ctx context.Context, e event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			r.enqueueSourceStore(ctx, q, e.Object)
+		},
+	}
+}
+
+func (r *BookStoreReconciler) enqueueSourceStore(ctx context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request],
+	obj client.Object) {
+	book, ok := obj.(*bookstoreexamplecomv1.Book)
+	if !ok || book.Spec.CopyOf == nil || book.Spec.CopyOf.Namespace == "" {
+		return
+	}
+	for _, req := range r.bookStoresNamed(ctx, book.Spec.CopyOf.Namespace) {
+		q.Add(req)
+	}
+}
+
+// bookStoresForNamespace maps a Namespace back to the BookStores named after it. It matches on the
+// name rather than the label, so a namespace whose labels were removed still finds its store.
+func (r *BookStoreReconciler) bookStoresForNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.bookStoresNamed(ctx, obj.GetName())
+}
+
+// bookStoresNamed returns a request for every BookStore called name.
+func (r *BookStoreReconciler) bookStoresNamed(ctx context.Context, name string) []reconcile.Request {
+	var bookstores bookstoreexamplecomv1.BookStoreList
+	if err := r.List(ctx, &bookstores, client.MatchingFields{stores.NameField: name}); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list BookStores for namespace", "namespace", name)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, bookstore := range bookstores.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: bookstore.Namespace, Name: bookstore.Name},
+		})
+	}
+	return requests
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *BookStoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&bookstoreexamplecomv1.BookStore{}).
+		For(&bookstoreexamplecomv1.BookStore{}, builder.WithPredicates(specOrMetadataChanged())).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.bookStoresForNamespace)).
+		Watches(&bookstoreexamplecomv1.Book{}, r.enqueueSourceStores(), builder.WithPredicates(copyReferenceChanged())).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.Config.Current().Controllers.BookStore.MaxConcurrentReconciles,
+		}).
 		Named("bookstore").
-		Complete(r)
+		Complete(tracing.Reconciler("BookStoreReconciler", r))
 }
@@ -18,16 +18,28 @@ package controller
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+	"github.com/danieldanieltata/bookstore-operator/internal/config"
+	"github.com/danieldanieltata/bookstore-operator/internal/dryrun"
 )
 
 var _ = Describe("BookStore Controller", func() {
@@ -68,9 +80,11 @@ var _ = Describe("BookStore Controller", func() {
 		})
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
+			recorder := events.NewFakeRecorder(100)
 			controllerReconciler := &BookStoreReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
 			}
 
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -81,4 +95,555 @@ var _ = Describe("BookStore Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+
+	Context("When the store namespace drifts", func() {
+		const storeName = "drifting-store"
+
+		ctx := context.Background()
+
+		storeKey := types.NamespacedName{Name: storeName, Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating the BookStore")
+			Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.BookStore{
+				ObjectMeta: metav1.ObjectMeta{Name: storeName, Namespace: "default"},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("removing the finalizer so the BookStore can go away")
+			resource := &bookstoreexamplecomv1.BookStore{}
+			Expect(k8sClient.Get(ctx, storeKey, resource)).To(Succeed())
+			controllerutil.RemoveFinalizer(resource, bookStoreFinalizer)
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should create, label and repair the namespace", func() {
+			recorder := events.NewFakeRecorder(100)
+			controllerReconciler := &BookStoreReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+
+			By("reconciling once to add the finalizer and create the namespace")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: storeKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			namespace := &corev1.Namespace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: storeName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue(bookstoreexamplecomv1.BookStoreLabel, storeName))
+			expectNamespaceReady(ctx, storeKey, "Created")
+			bookstore := &bookstoreexamplecomv1.BookStore{}
+			Expect(k8sClient.Get(ctx, storeKey, bookstore)).To(Succeed())
+			Expect(bookstore.Status.ObservedGeneration).To(Equal(bookstore.Generation))
+			Expect(receivedEvents(recorder)).To(ContainElement("Normal NamespaceCreated Created namespace " + storeName))
+
+			By("mapping the namespace back to its BookStore")
+			Expect(controllerReconciler.bookStoresForNamespace(ctx, namespace)).To(ConsistOf(
+				reconcile.Request{NamespacedName: storeKey},
+			))
+
+			By("stripping the namespace labels")
+			namespace.Labels = nil
+			Expect(k8sClient.Update(ctx, namespace)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: storeKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: storeName}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue(bookstoreexamplecomv1.BookStoreLabel, storeName))
+			expectNamespaceReady(ctx, storeKey, "Repaired")
+			Expect(receivedEvents(recorder)).To(ContainElement("Normal NamespaceRepaired Restored labels on namespace " + storeName))
+		})
+	})
+
+	Context("When the store namespace is deleted", func() {
+		const storeName = "vanishing-store"
+
+		ctx := context.Background()
+
+		storeKey := types.NamespacedName{Name: storeName, Namespace: "default"}
+
+		AfterEach(func() {
+			By("removing the finalizer so the BookStore can go away")
+			resource := &bookstoreexamplecomv1.BookStore{}
+			Expect(k8sClient.Get(ctx, storeKey, resource)).To(Succeed())
+			controllerutil.RemoveFinalizer(resource, bookStoreFinalizer)
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should leave the BookStore to the garbage collector instead of recreating the namespace", func() {
+			recorder := events.NewFakeRecorder(100)
+			controllerReconciler := &BookStoreReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+
+			By("creating the BookStore and its namespace")
+			Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.BookStore{
+				ObjectMeta: metav1.ObjectMeta{Name: storeName, Namespace: "default"},
+			})).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: storeKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			namespace := &corev1.Namespace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: storeName}, namespace)).To(Succeed())
+			bookstore := &bookstoreexamplecomv1.BookStore{}
+			Expect(k8sClient.Get(ctx, storeKey, bookstore)).To(Succeed())
+			Expect(bookstore.OwnerReferences).To(ConsistOf(metav1.OwnerReference{
+				APIVersion: "v1", Kind: "Namespace", Name: storeName, UID: namespace.UID,
+			}))
+
+			By("deleting the namespace, which envtest leaves terminating")
+			Expect(k8sClient.Delete(ctx, namespace)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: storeKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, storeKey, bookstore)).To(Succeed())
+			cond := meta.FindStatusCondition(bookstore.Status.Conditions, bookstoreexamplecomv1.ConditionNamespaceReady)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("Terminating"))
+			Expect(receivedEvents(recorder)).To(ContainElement(
+				"Warning NamespaceTerminating Namespace " + storeName + " is being deleted, the BookStore is deleted with it"))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: storeName}, namespace)).To(Succeed())
+			Expect(namespace.DeletionTimestamp).NotTo(BeNil())
+		})
+	})
+
+	Context("When a BookStore with Books is deleted", func() {
+		const storeName = "closing-store"
+
+		ctx := context.Background()
+
+		storeKey := types.NamespacedName{Name: storeName, Namespace: "default"}
+
+		It("should record an Event for each cleanup step", func() {
+			recorder := events.NewFakeRecorder(100)
+			controllerReconciler := &BookStoreReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+
+			By("creating the BookStore and its namespace")
+			Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.BookStore{
+				ObjectMeta: metav1.ObjectMeta{Name: storeName, Namespace: "default"},
+			})).To(Succeed())
+			for range 2 {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: storeKey})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			By("adding an original to the store and a copy of it elsewhere")
+			Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{Name: "closing-original", Namespace: storeName},
+				Spec:       bookstoreexamplecomv1.BookSpec{Title: "Original", Price: "10", Genre: "Fiction"},
+			})).To(Succeed())
+			remoteCopy := &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{Name: "closing-copy", Namespace: "default"},
+				Spec: bookstoreexamplecomv1.BookSpec{
+					Title:  "Copy",
+					CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: storeName, Name: "closing-original"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, remoteCopy)).To(Succeed())
+
+			By("deleting the BookStore and running the finalizer")
+			receivedEvents(recorder)
+			bookstore := &bookstoreexamplecomv1.BookStore{}
+			Expect(k8sClient.Get(ctx, storeKey, bookstore)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, bookstore)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: storeKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(receivedEvents(recorder)).To(ConsistOf(
+				HavePrefix("Normal CleanupStarted "),
+				"Normal BooksDeleted Deleted 1 Books in namespace "+storeName,
+				"Normal RemoteCopyDeleted Deleted Book default/closing-copy, a copy of closing-original",
+				HavePrefix("Normal CleanupCompleted "),
+			))
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(remoteCopy), remoteCopy))).To(BeTrue())
+		})
+
+		It("should split the cleanup into batches", func() {
+			const batchedStore = "batched-store"
+			batchedKey := types.NamespacedName{Name: batchedStore, Namespace: "default"}
+
+			configPath := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+			Expect(os.WriteFile(configPath, []byte(
+				"apiVersion: bookstore.example.com/v1alpha1\nkind: OperatorConfig\ncleanup:\n  batchSize: 1\n",
+			), 0o600)).To(Succeed())
+			operatorConfig, err := config.NewWatcher(configPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			recorder := events.NewFakeRecorder(100)
+			controllerReconciler := &BookStoreReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+				Config:   operatorConfig,
+			}
+
+			By("creating a BookStore with two Books")
+			Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.BookStore{
+				ObjectMeta: metav1.ObjectMeta{Name: batchedStore, Namespace: "default"},
+			})).To(Succeed())
+			for range 2 {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: batchedKey})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			for _, name := range []string{"first", "second"} {
+				Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.Book{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: batchedStore},
+					Spec:       bookstoreexamplecomv1.BookSpec{Title: name, Price: "10", Genre: "Fiction"},
+				})).To(Succeed())
+			}
+
+			By("deleting the BookStore")
+			receivedEvents(recorder)
+			bookstore := &bookstoreexamplecomv1.BookStore{}
+			Expect(k8sClient.Get(ctx, batchedKey, bookstore)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, bookstore)).To(Succeed())
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: batchedKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			var books bookstoreexamplecomv1.BookList
+			Expect(k8sClient.List(ctx, &books, client.InNamespace(batchedStore))).To(Succeed())
+			Expect(books.Items).To(HaveLen(1))
+			Expect(k8sClient.Get(ctx, batchedKey, bookstore)).To(Succeed())
+
+			By("finishing the cleanup on the next pass")
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: batchedKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.List(ctx, &books, client.InNamespace(batchedStore))).To(Succeed())
+			Expect(books.Items).To(BeEmpty())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, batchedKey, bookstore))).To(BeTrue())
+
+			By("announcing the cleanup once")
+			var started []string
+			for _, event := range receivedEvents(recorder) {
+				if strings.HasPrefix(event, "Normal CleanupStarted ") {
+					started = append(started, event)
+				}
+			}
+			Expect(started).To(HaveLen(1))
+		})
+
+		It("should report Books outside the watched namespaces instead of failing", func() {
+			const unwatchedStore = "unwatched-store"
+			unwatchedKey := types.NamespacedName{Name: unwatchedStore, Namespace: "default"}
+
+			recorder := events.NewFakeRecorder(100)
+			controllerReconciler := &BookStoreReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+				Scope:    NewWatchScope("default"),
+			}
+
+			By("creating a BookStore whose namespace is not watched")
+			Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.BookStore{
+				ObjectMeta: metav1.ObjectMeta{Name: unwatchedStore, Namespace: "default"},
+			})).To(Succeed())
+			for range 2 {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: unwatchedKey})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			By("deleting the BookStore")
+			receivedEvents(recorder)
+			bookstore := &bookstoreexamplecomv1.BookStore{}
+			Expect(k8sClient.Get(ctx, unwatchedKey, bookstore)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, bookstore)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: unwatchedKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(receivedEvents(recorder)).To(ContainElements(
+				"Warning BooksNotWatched Namespace "+unwatchedStore+" is outside the watched namespaces, its Books were not deleted",
+				HavePrefix("Warning BooksNotWatched Copies in namespaces outside [default] "),
+				HavePrefix("Normal CleanupCompleted "),
+			))
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, unwatchedKey, bookstore))).To(BeTrue())
+		})
+
+		It("should simulate the whole cleanup once in a dry run", func() {
+			const dryRunStore = "dry-run-store"
+			dryRunKey := types.NamespacedName{Name: dryRunStore, Namespace: "default"}
+
+			controllerReconciler := &BookStoreReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: events.NewFakeRecorder(100),
+			}
+
+			By("creating a BookStore with two Books")
+			Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.BookStore{
+				ObjectMeta: metav1.ObjectMeta{Name: dryRunStore, Namespace: "default"},
+			})).To(Succeed())
+			for range 2 {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: dryRunKey})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			for _, name := range []string{"first", "second"} {
+				Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.Book{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: dryRunStore},
+					Spec:       bookstoreexamplecomv1.BookSpec{Title: name, Price: "10", Genre: "Fiction"},
+				})).To(Succeed())
+			}
+
+			By("deleting the BookStore under a dry-run reconciler with batches of one")
+			bookstore := &bookstoreexamplecomv1.BookStore{}
+			Expect(k8sClient.Get(ctx, dryRunKey, bookstore)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, bookstore)).To(Succeed())
+
+			recorder := events.NewFakeRecorder(100)
+			dryRunReconciler := &BookStoreReconciler{
+				Client:   dryrun.NewClient(k8sClient),
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+				Config:   configWatcher("cleanup:\n  batchSize: 1\n"),
+				DryRun:   true,
+			}
+			for range 2 {
+				result, err := dryRunReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: dryRunKey})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+			}
+
+			Expect(receivedEvents(recorder)).To(ConsistOf(
+				HavePrefix("Normal CleanupStarted "),
+				"Normal BooksDeleted Deleted 2 Books in namespace "+dryRunStore,
+				HavePrefix("Normal CleanupCompleted "),
+			))
+			var books bookstoreexamplecomv1.BookList
+			Expect(k8sClient.List(ctx, &books, client.InNamespace(dryRunStore))).To(Succeed())
+			Expect(books.Items).To(HaveLen(2))
+			Expect(k8sClient.Get(ctx, dryRunKey, bookstore)).To(Succeed())
+			Expect(bookstore.Finalizers).To(ContainElement(bookStoreFinalizer))
+
+			By("running the real cleanup")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: dryRunKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, dryRunKey, bookstore))).To(BeTrue())
+		})
+	})
+
+	Context("When a BookStore is paused", func() {
+		const storeName = "paused-store"
+
+		ctx := context.Background()
+
+		storeKey := types.NamespacedName{Name: storeName, Namespace: "default"}
+
+		It("should hold back the finalizer cleanup until it is unpaused", func() {
+			recorder := events.NewFakeRecorder(100)
+			controllerReconciler := &BookStoreReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+
+			By("creating the BookStore with a Book")
+			Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.BookStore{
+				ObjectMeta: metav1.ObjectMeta{Name: storeName, Namespace: "default"},
+			})).To(Succeed())
+			for range 2 {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: storeKey})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			book := &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{Name: "paused-store-book", Namespace: storeName},
+				Spec:       bookstoreexamplecomv1.BookSpec{Title: "Original", Price: "10", Genre: "Fiction"},
+			}
+			Expect(k8sClient.Create(ctx, book)).To(Succeed())
+
+			By("pausing and deleting the BookStore")
+			bookstore := &bookstoreexamplecomv1.BookStore{}
+			Expect(k8sClient.Get(ctx, storeKey, bookstore)).To(Succeed())
+			bookstore.Annotations = map[string]string{bookstoreexamplecomv1.PausedAnnotation: "true"}
+			Expect(k8sClient.Update(ctx, bookstore)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, bookstore)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: storeKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, storeKey, bookstore)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(bookstore.Status.Conditions, bookstoreexamplecomv1.ConditionPaused)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(book), book)).To(Succeed())
+			Expect(receivedEvents(recorder)).To(ContainElement(HavePrefix("Normal Paused ")))
+
+			By("unpausing the BookStore")
+			delete(bookstore.Annotations, bookstoreexamplecomv1.PausedAnnotation)
+			Expect(k8sClient.Update(ctx, bookstore)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: storeKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(book), book))).To(BeTrue())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, storeKey, bookstore))).To(BeTrue())
+		})
+
+		It("should wait for paused Books before cleaning up", func() {
+			const blockedStore = "blocked-store"
+			blockedKey := types.NamespacedName{Name: blockedStore, Namespace: "default"}
+
+			recorder := events.NewFakeRecorder(100)
+			controllerReconciler := &BookStoreReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+
+			Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.BookStore{
+				ObjectMeta: metav1.ObjectMeta{Name: blockedStore, Namespace: "default"},
+			})).To(Succeed())
+			for range 2 {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: blockedKey})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			remoteCopy := &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "paused-copy",
+					Namespace:   "default",
+					Annotations: map[string]string{bookstoreexamplecomv1.PausedAnnotation: "true"},
+				},
+				Spec: bookstoreexamplecomv1.BookSpec{
+					Title:  "Copy",
+					CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: blockedStore, Name: "original"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, remoteCopy)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, remoteCopy))).To(Succeed())
+			})
+
+			bookstore := &bookstoreexamplecomv1.BookStore{}
+			Expect(k8sClient.Get(ctx, blockedKey, bookstore)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, bookstore)).To(Succeed())
+			receivedEvents(recorder)
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: blockedKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(pausedBooksDelay))
+
+			Expect(receivedEvents(recorder)).To(ConsistOf(
+				"Warning CleanupBlocked Waiting for paused Books to be unpaused: default/paused-copy",
+			))
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(remoteCopy), remoteCopy)).To(Succeed())
+
+			By("unpausing the copy")
+			remoteCopy.Annotations = nil
+			Expect(k8sClient.Update(ctx, remoteCopy)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: blockedKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(remoteCopy), remoteCopy))).To(BeTrue())
+		})
+	})
+
+	Context("When other stores hold copies", func() {
+		const sourceName = "lending-store"
+		const holderName = "borrowing-store"
+
+		ctx := context.Background()
+
+		sourceKey := types.NamespacedName{Name: sourceName, Namespace: "default"}
+		holderKey := types.NamespacedName{Name: holderName, Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating two stores and their namespaces")
+			controllerReconciler := &BookStoreReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: events.NewFakeRecorder(100),
+			}
+			for _, key := range []types.NamespacedName{sourceKey, holderKey} {
+				Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.BookStore{
+					ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				})).To(Succeed())
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		AfterEach(func() {
+			for _, namespace := range []string{sourceName, holderName} {
+				Expect(k8sClient.DeleteAllOf(ctx, &bookstoreexamplecomv1.Book{}, client.InNamespace(namespace))).To(Succeed())
+			}
+			for _, key := range []types.NamespacedName{sourceKey, holderKey} {
+				resource := &bookstoreexamplecomv1.BookStore{}
+				Expect(k8sClient.Get(ctx, key, resource)).To(Succeed())
+				controllerutil.RemoveFinalizer(resource, bookStoreFinalizer)
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			}
+		})
+
+		It("should list them in copyHolders", func() {
+			controllerReconciler := &BookStoreReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: events.NewFakeRecorder(100),
+			}
+
+			By("copying a Book from one store into the other")
+			Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{Name: "lent-original", Namespace: sourceName},
+				Spec:       bookstoreexamplecomv1.BookSpec{Title: "Original", Price: "10", Genre: "Fiction"},
+			})).To(Succeed())
+			lentCopy := &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{Name: "lent-copy", Namespace: holderName},
+				Spec: bookstoreexamplecomv1.BookSpec{
+					Price:  "12",
+					CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: sourceName, Name: "lent-original"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, lentCopy)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: sourceKey})
+			Expect(err).NotTo(HaveOccurred())
+			bookstore := &bookstoreexamplecomv1.BookStore{}
+			Expect(k8sClient.Get(ctx, sourceKey, bookstore)).To(Succeed())
+			Expect(bookstore.Status.CopyHolders).To(Equal([]string{holderName}))
+
+			By("enqueueing the source store when the copy changes")
+			queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			defer queue.ShutDown()
+			controllerReconciler.enqueueSourceStores().Delete(ctx, event.DeleteEvent{Object: lentCopy}, queue)
+			Expect(queue.Len()).To(Equal(1))
+			req, _ := queue.Get()
+			Expect(req).To(Equal(reconcile.Request{NamespacedName: sourceKey}))
+
+			By("dropping the holder once its copy is gone")
+			Expect(k8sClient.Delete(ctx, lentCopy)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: sourceKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, sourceKey, bookstore)).To(Succeed())
+			Expect(bookstore.Status.CopyHolders).To(BeEmpty())
+		})
+	})
 })
+
+// configWatcher returns a config watcher reading an OperatorConfig with the given sections.
+func configWatcher(sections string) *config.Watcher {
+	GinkgoHelper()
+	configPath := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+	Expect(os.WriteFile(configPath, []byte(
+		"apiVersion: bookstore.example.com/v1alpha1\nkind: OperatorConfig\n"+sections,
+	), 0o600)).To(Succeed())
+	operatorConfig, err := config.NewWatcher(configPath)
+	Expect(err).NotTo(HaveOccurred())
+	return operatorConfig
+}
+
+func expectNamespaceReady(ctx context.Context, key types.NamespacedName, reason string) {
+	GinkgoHelper()
+	bookstore := &bookstoreexamplecomv1.BookStore{}
+	Expect(k8sClient.Get(ctx, key, bookstore)).To(Succeed())
+	cond := meta.FindStatusCondition(bookstore.Status.Conditions, bookstoreexamplecomv1.ConditionNamespaceReady)
+	Expect(cond).NotTo(BeNil())
+	Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+	Expect(cond.Reason).To(Equal(reason))
+}
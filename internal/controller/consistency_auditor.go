@@ -0,0 +1,255 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+	"github.com/danieldanieltata/bookstore-operator/internal/config"
+	"github.com/danieldanieltata/bookstore-operator/internal/metrics"
+)
+
+// ConsistencyReportName is the name of the cluster-scoped ConsistencyReport the auditor keeps up to date.
+const ConsistencyReportName = "bookstore-operator"
+
+// maxReportedFindings caps the findings written to the report, so a badly broken cluster does not
+// push it past the object size limit. The report's findingCount still counts all of them.
+const maxReportedFindings = 200
+
+// auditDisabledPoll is how often a disabled auditor checks whether the configuration enabled it.
+const auditDisabledPoll = time.Minute
+
+var auditorLog = logf.Log.WithName("consistency-auditor")
+
+// ConsistencyAuditor periodically scans for inconsistencies the controllers do not fix on their own,
+// or that only show up across objects, and writes them to the ConsistencyReport. With audit.repair
+// set it also fixes the safe ones. It runs on the leader only.
+type ConsistencyAuditor struct {
+	client.Client
+	Config *config.Watcher
+	// Scope is the set of namespaces the manager cache covers. The zero value is the whole cluster.
+	Scope WatchScope
+
+	// now returns the current time, it is replaced in tests.
+	now func() time.Time
+}
+
+// +kubebuilder:rbac:groups=bookstore.example.com,resources=consistencyreports,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=bookstore.example.com,resources=consistencyreports/status,verbs=get;update;patch
+
+// Start runs an audit every audit.interval until ctx is done. Errors are logged and the next run
+// tries again.
+func (a *ConsistencyAuditor) Start(ctx context.Context) error {
+	for {
+		wait := a.Config.Current().Audit.Interval.Duration
+		if wait == 0 {
+			wait = auditDisabledPoll
+		} else if _, err := a.Audit(ctx); err != nil {
+			auditorLog.Error(err, "Consistency audit failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// NeedLeaderElection keeps the auditor, and its repairs, on the leader.
+func (a *ConsistencyAuditor) NeedLeaderElection() bool {
+	return true
+}
+
+// Audit runs a single scan, repairs the safe findings when audit.repair is set, and writes the
+// outcome to the ConsistencyReport.
+func (a *ConsistencyAuditor) Audit(ctx context.Context) (*bookstoreexamplecomv1.ConsistencyReportStatus, error) {
+	cfg := a.Config.Current().Audit
+
+	findings, err := a.scan(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &bookstoreexamplecomv1.ConsistencyReportStatus{
+		LastRunTime:  &metav1.Time{Time: a.clock()},
+		FindingCount: len(findings),
+	}
+	metrics.ConsistencyFindings.Reset()
+	for _, finding := range findings {
+		metrics.ConsistencyFindings.WithLabelValues(string(finding.Type)).Inc()
+		if finding.Repaired {
+			status.RepairedCount++
+		}
+	}
+	status.Findings = findings[:min(len(findings), maxReportedFindings)]
+
+	if err := a.writeReport(ctx, status); err != nil {
+		return nil, err
+	}
+	auditorLog.Info("Consistency audit finished", "findings", status.FindingCount, "repaired", status.RepairedCount)
+	return status, nil
+}
+
+// scan lists BookStores, Books and store Namespaces once and checks them against each other.
+func (a *ConsistencyAuditor) scan(ctx context.Context, cfg config.AuditConfig) ([]bookstoreexamplecomv1.Finding, error) {
+	var bookstores bookstoreexamplecomv1.BookStoreList
+	if err := a.List(ctx, &bookstores); err != nil {
+		return nil, err
+	}
+	var books bookstoreexamplecomv1.BookList
+	if err := a.List(ctx, &books); err != nil {
+		return nil, err
+	}
+	var namespaces corev1.NamespaceList
+	if err := a.List(ctx, &namespaces, client.HasLabels{bookstoreexamplecomv1.BookStoreLabel}); err != nil {
+		return nil, err
+	}
+
+	storesByName := make(map[string]*bookstoreexamplecomv1.BookStore, len(bookstores.Items))
+	for i := range bookstores.Items {
+		storesByName[bookstores.Items[i].Name] = &bookstores.Items[i]
+	}
+	booksByKey := make(map[types.NamespacedName]bool, len(books.Items))
+	for _, book := range books.Items {
+		booksByKey[types.NamespacedName{Namespace: book.Namespace, Name: book.Name}] = true
+	}
+
+	var findings []bookstoreexamplecomv1.Finding
+	add := func(findingType bookstoreexamplecomv1.FindingType, kind, namespace, name, message string, repaired bool) {
+		findings = append(findings, bookstoreexamplecomv1.Finding{
+			Type: findingType, Kind: kind, Namespace: namespace, Name: name, Message: message, Repaired: repaired,
+		})
+	}
+
+	for i := range books.Items {
+		book := &books.Items[i]
+
+		if _, ok := storesByName[book.Namespace]; !ok {
+			add(bookstoreexamplecomv1.FindingUnmanagedBook, "Book", book.Namespace, book.Name,
+				"namespace "+book.Namespace+" is not managed by any BookStore", false)
+		}
+
+		if book.Spec.CopyOf != nil {
+			target := types.NamespacedName{Namespace: book.Spec.CopyOf.Namespace, Name: book.Spec.CopyOf.Name}
+			// A target outside the watch scope is not in the cache, so its absence proves nothing.
+			if a.Scope.Contains(target.Namespace) && !booksByKey[target] {
+				add(bookstoreexamplecomv1.FindingDanglingCopyOf, "Book", book.Namespace, book.Name,
+					fmt.Sprintf("spec.copyOf points at %s, which does not exist", target), false)
+			}
+			continue
+		}
+
+		// Paused and deleted originals are expected to lag behind their copies.
+		if bookstoreexamplecomv1.IsPaused(book) || book.DeletionTimestamp != nil {
+			continue
+		}
+		if store := storesByName[book.Namespace]; store != nil && bookstoreexamplecomv1.IsPaused(store) {
+			continue
+		}
+		referenceCount := countReferences(book, books.Items)
+		if referenceCount == book.Status.ReferenceCount {
+			continue
+		}
+		message := fmt.Sprintf("status.referenceCount is %d, but %d copies point at it", book.Status.ReferenceCount, referenceCount)
+		repaired := false
+		if cfg.Repair {
+			if err := a.repairReferenceCount(ctx, book); err != nil {
+				auditorLog.Error(err, "Failed to repair referenceCount", "book", book.Name, "namespace", book.Namespace)
+				message += ", repair failed: " + err.Error()
+			} else {
+				repaired = true
+			}
+		}
+		add(bookstoreexamplecomv1.FindingWrongReferenceCount, "Book", book.Namespace, book.Name, message, repaired)
+	}
+
+	for _, namespace := range namespaces.Items {
+		storeName := namespace.Labels[bookstoreexamplecomv1.BookStoreLabel]
+		if _, ok := storesByName[storeName]; ok {
+			continue
+		}
+		add(bookstoreexamplecomv1.FindingOrphanStoreNamespace, "Namespace", "", namespace.Name,
+			"labeled for BookStore "+storeName+", which does not exist", false)
+	}
+
+	for _, bookstore := range bookstores.Items {
+		if bookstore.DeletionTimestamp == nil {
+			continue
+		}
+		terminating := a.clock().Sub(bookstore.DeletionTimestamp.Time)
+		if terminating < cfg.StuckTerminatingAfter.Duration {
+			continue
+		}
+		add(bookstoreexamplecomv1.FindingStuckTerminating, "BookStore", bookstore.Namespace, bookstore.Name,
+			fmt.Sprintf("terminating for %s, waiting on finalizers %s",
+				terminating.Round(time.Second), strings.Join(bookstore.Finalizers, ", ")), false)
+	}
+
+	slices.SortFunc(findings, func(x, y bookstoreexamplecomv1.Finding) int {
+		return cmp.Or(
+			cmp.Compare(x.Type, y.Type),
+			cmp.Compare(x.Namespace, y.Namespace),
+			cmp.Compare(x.Name, y.Name),
+		)
+	})
+	return findings, nil
+}
+
+// repairReferenceCount recounts and writes referenceCount through the Book controller's own path, so
+// a count that changed since the scan is recounted rather than overwritten with the scanned one.
+func (a *ConsistencyAuditor) repairReferenceCount(ctx context.Context, book *bookstoreexamplecomv1.Book) error {
+	_, _, err := patchReferenceCount(ctx, a.Client, client.ObjectKeyFromObject(book))
+	return err
+}
+
+// writeReport creates the ConsistencyReport if needed and replaces its status.
+func (a *ConsistencyAuditor) writeReport(ctx context.Context, status *bookstoreexamplecomv1.ConsistencyReportStatus) error {
+	report := &bookstoreexamplecomv1.ConsistencyReport{}
+	err := a.Get(ctx, types.NamespacedName{Name: ConsistencyReportName}, report)
+	if errors.IsNotFound(err) {
+		report = &bookstoreexamplecomv1.ConsistencyReport{ObjectMeta: metav1.ObjectMeta{Name: ConsistencyReportName}}
+		err = a.Create(ctx, report, client.FieldOwner(fieldManager))
+	}
+	if err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(report.DeepCopy())
+	report.Status = *status
+	return a.Status().Patch(ctx, report, patch, client.FieldOwner(fieldManager))
+}
+
+func (a *ConsistencyAuditor) clock() time.Time {
+	if a.now != nil {
+		return a.now()
+	}
+	return time.Now()
+}
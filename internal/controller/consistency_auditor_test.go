@@ -0,0 +1,178 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+	"github.com/danieldanieltata/bookstore-operator/internal/config"
+)
+
+var _ = Describe("Consistency Auditor", func() {
+	Context("When the cluster has drifted", func() {
+		const originalName = "audited-original"
+		const orphanNamespace = "audit-orphan"
+
+		ctx := context.Background()
+
+		originalKey := types.NamespacedName{Name: originalName, Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating an original with a wrong referenceCount, a copy of it and a dangling copy")
+			original := &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{Name: originalName, Namespace: "default"},
+				Spec:       bookstoreexamplecomv1.BookSpec{Title: "Original", Price: "10", Genre: "Fiction"},
+			}
+			Expect(k8sClient.Create(ctx, original)).To(Succeed())
+			original.Status.ReferenceCount = 3
+			Expect(k8sClient.Status().Update(ctx, original)).To(Succeed())
+			Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{Name: "audited-copy", Namespace: "default"},
+				Spec: bookstoreexamplecomv1.BookSpec{
+					Title:  "Copy",
+					CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: "default", Name: originalName},
+				},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.Book{
+				ObjectMeta: metav1.ObjectMeta{Name: "dangling-copy", Namespace: "default"},
+				Spec: bookstoreexamplecomv1.BookSpec{
+					Title:  "Dangling",
+					CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: "default", Name: "missing-original"},
+				},
+			})).To(Succeed())
+
+			By("creating a namespace labeled for a BookStore that does not exist")
+			namespace := &corev1.Namespace{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: orphanNamespace}, namespace); err != nil {
+				Expect(k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+					Name:   orphanNamespace,
+					Labels: map[string]string{bookstoreexamplecomv1.BookStoreLabel: "gone-store"},
+				}})).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.DeleteAllOf(ctx, &bookstoreexamplecomv1.Book{}, client.InNamespace("default"))).To(Succeed())
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &bookstoreexamplecomv1.ConsistencyReport{
+				ObjectMeta: metav1.ObjectMeta{Name: ConsistencyReportName},
+			}))).To(Succeed())
+		})
+
+		It("should report every finding without touching the cluster", func() {
+			auditor := &ConsistencyAuditor{Client: k8sClient}
+
+			status, err := auditor.Audit(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Findings).To(ContainElements(
+				HaveField("Type", bookstoreexamplecomv1.FindingDanglingCopyOf),
+				HaveField("Type", bookstoreexamplecomv1.FindingWrongReferenceCount),
+				HaveField("Type", bookstoreexamplecomv1.FindingOrphanStoreNamespace),
+			))
+			Expect(status.RepairedCount).To(BeZero())
+
+			original := &bookstoreexamplecomv1.Book{}
+			Expect(k8sClient.Get(ctx, originalKey, original)).To(Succeed())
+			Expect(original.Status.ReferenceCount).To(Equal(3))
+
+			By("writing the report")
+			report := &bookstoreexamplecomv1.ConsistencyReport{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: ConsistencyReportName}, report)).To(Succeed())
+			Expect(report.Status.FindingCount).To(Equal(status.FindingCount))
+			Expect(report.Status.LastRunTime).NotTo(BeNil())
+		})
+
+		It("should repair the referenceCount when repair is enabled", func() {
+			auditor := &ConsistencyAuditor{Client: k8sClient, Config: repairingConfig()}
+			status, err := auditor.Audit(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Findings).To(ContainElement(And(
+				HaveField("Type", bookstoreexamplecomv1.FindingWrongReferenceCount),
+				HaveField("Name", originalName),
+				HaveField("Repaired", true),
+			)))
+
+			original := &bookstoreexamplecomv1.Book{}
+			Expect(k8sClient.Get(ctx, originalKey, original)).To(Succeed())
+			Expect(original.Status.ReferenceCount).To(Equal(1))
+
+			By("finding nothing left to repair on the next run")
+			status, err = auditor.Audit(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Findings).NotTo(ContainElement(HaveField("Name", originalName)))
+		})
+
+		It("should recount instead of overwriting a referenceCount that changed since the scan", func() {
+			watchClient, err := client.NewWithWatch(cfg, client.Options{Scheme: k8sClient.Scheme()})
+			Expect(err).NotTo(HaveOccurred())
+
+			raced := false
+			auditor := &ConsistencyAuditor{Config: repairingConfig(), Client: interceptor.NewClient(watchClient, interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResource string, obj client.Object,
+					patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					if _, ok := obj.(*bookstoreexamplecomv1.Book); ok && !raced {
+						raced = true
+						By("adding a second copy the Book controller counts before the repair lands")
+						Expect(k8sClient.Create(ctx, &bookstoreexamplecomv1.Book{
+							ObjectMeta: metav1.ObjectMeta{Name: "audited-second-copy", Namespace: "default"},
+							Spec: bookstoreexamplecomv1.BookSpec{
+								Title:  "Second copy",
+								CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: "default", Name: originalName},
+							},
+						})).To(Succeed())
+						original := &bookstoreexamplecomv1.Book{}
+						Expect(k8sClient.Get(ctx, originalKey, original)).To(Succeed())
+						original.Status.ReferenceCount = 2
+						Expect(k8sClient.Status().Update(ctx, original)).To(Succeed())
+					}
+					return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+				},
+			})}
+
+			_, err = auditor.Audit(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(raced).To(BeTrue())
+
+			original := &bookstoreexamplecomv1.Book{}
+			Expect(k8sClient.Get(ctx, originalKey, original)).To(Succeed())
+			Expect(original.Status.ReferenceCount).To(Equal(2))
+		})
+	})
+})
+
+// repairingConfig returns a config watcher with audit.repair turned on.
+func repairingConfig() *config.Watcher {
+	GinkgoHelper()
+	configPath := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+	Expect(os.WriteFile(configPath, []byte(
+		"apiVersion: bookstore.example.com/v1alpha1\nkind: OperatorConfig\naudit:\n  repair: true\n",
+	), 0o600)).To(Succeed())
+	operatorConfig, err := config.NewWatcher(configPath)
+	Expect(err).NotTo(HaveOccurred())
+	return operatorConfig
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+)
+
+// WatchScope is the set of namespaces the manager cache is restricted to. Books outside it are
+// invisible to the controllers, so they report them instead of acting on them. The zero value
+// covers the whole cluster.
+type WatchScope struct {
+	namespaces map[string]struct{}
+}
+
+// NewWatchScope returns a scope restricted to namespaces, or the cluster-wide scope when it is empty.
+func NewWatchScope(namespaces ...string) WatchScope {
+	if len(namespaces) == 0 {
+		return WatchScope{}
+	}
+	scope := WatchScope{namespaces: make(map[string]struct{}, len(namespaces))}
+	for _, namespace := range namespaces {
+		scope.namespaces[namespace] = struct{}{}
+	}
+	return scope
+}
+
+// Restricted reports whether the scope covers less than the whole cluster.
+func (s WatchScope) Restricted() bool {
+	return len(s.namespaces) > 0
+}
+
+// Contains reports whether Books in namespace are visible through the cache.
+func (s WatchScope) Contains(namespace string) bool {
+	if !s.Restricted() {
+		return true
+	}
+	_, ok := s.namespaces[namespace]
+	return ok
+}
+
+// Namespaces returns the watched namespaces in sorted order, or nil for the cluster-wide scope.
+func (s WatchScope) Namespaces() []string {
+	if !s.Restricted() {
+		return nil
+	}
+	namespaces := make([]string, 0, len(s.namespaces))
+	for namespace := range s.namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	slices.Sort(namespaces)
+	return namespaces
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package decisions records what the admission webhooks decide: every decision is counted in
+// metrics.WebhookDecisions and timed in metrics.WebhookDuration, and every denial is written as one
+// JSON line to a denial log, so the rules that block users most can be charted and looked up.
+package decisions
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"regexp"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/danieldanieltata/bookstore-operator/internal/metrics"
+)
+
+// NewDenialLog returns a logger that writes denials to w as JSON lines. A nil logger disables the
+// denial log.
+func NewDenialLog(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, nil))
+}
+
+// Validator records the decisions of v under the webhook label name and logs its denials to denials.
+func Validator[T runtime.Object](name string, denials *slog.Logger, v admission.Validator[T]) admission.Validator[T] {
+	return &recordedValidator[T]{name: name, denials: denials, next: v}
+}
+
+type recordedValidator[T runtime.Object] struct {
+	name    string
+	denials *slog.Logger
+	next    admission.Validator[T]
+}
+
+func (r *recordedValidator[T]) ValidateCreate(ctx context.Context, obj T) (admission.Warnings, error) {
+	start := time.Now()
+	warnings, err := r.next.ValidateCreate(ctx, obj)
+	record(ctx, r.name, r.denials, "CREATE", obj, start, warnings, err)
+	return warnings, err
+}
+
+func (r *recordedValidator[T]) ValidateUpdate(ctx context.Context, oldObj, newObj T) (admission.Warnings, error) {
+	start := time.Now()
+	warnings, err := r.next.ValidateUpdate(ctx, oldObj, newObj)
+	record(ctx, r.name, r.denials, "UPDATE", newObj, start, warnings, err)
+	return warnings, err
+}
+
+func (r *recordedValidator[T]) ValidateDelete(ctx context.Context, obj T) (admission.Warnings, error) {
+	start := time.Now()
+	warnings, err := r.next.ValidateDelete(ctx, obj)
+	record(ctx, r.name, r.denials, "DELETE", obj, start, warnings, err)
+	return warnings, err
+}
+
+// Defaulter records the decisions of d under the webhook label name and logs its denials to denials.
+// A defaulter either admits or, when it fails, denies.
+func Defaulter[T runtime.Object](name string, denials *slog.Logger, d admission.Defaulter[T]) admission.Defaulter[T] {
+	return &recordedDefaulter[T]{name: name, denials: denials, next: d}
+}
+
+type recordedDefaulter[T runtime.Object] struct {
+	name    string
+	denials *slog.Logger
+	next    admission.Defaulter[T]
+}
+
+func (r *recordedDefaulter[T]) Default(ctx context.Context, obj T) error {
+	start := time.Now()
+	err := r.next.Default(ctx, obj)
+	operation := "UNKNOWN"
+	if req, reqErr := admission.RequestFromContext(ctx); reqErr == nil {
+		operation = string(req.Operation)
+	}
+	record(ctx, r.name, r.denials, operation, obj, start, nil, err)
+	return err
+}
+
+func record(ctx context.Context, webhook string, denials *slog.Logger, operation string, obj runtime.Object,
+	start time.Time, warnings admission.Warnings, err error) {
+	decision, reasons := metrics.DecisionAllow, []string{reasonNone}
+	switch {
+	case err != nil:
+		decision, reasons = metrics.DecisionDeny, denialReasons(err)
+	case len(warnings) > 0:
+		decision, reasons = metrics.DecisionWarn, make([]string, 0, len(warnings))
+		for _, warning := range warnings {
+			reasons = append(reasons, warningReason(warning))
+		}
+	}
+	metrics.WebhookDuration.WithLabelValues(webhook, operation, decision).Observe(time.Since(start).Seconds())
+	for _, reason := range reasons {
+		metrics.WebhookDecisions.WithLabelValues(webhook, operation, decision, reason).Inc()
+	}
+
+	if err == nil || denials == nil {
+		return
+	}
+	var user, kind string
+	if req, reqErr := admission.RequestFromContext(ctx); reqErr == nil {
+		user, kind = req.UserInfo.Username, req.Kind.Kind
+	}
+	var namespace, name string
+	if accessor, accessorErr := meta.Accessor(obj); accessorErr == nil {
+		namespace, name = accessor.GetNamespace(), accessor.GetName()
+	}
+	denials.LogAttrs(ctx, slog.LevelInfo, "admission denied",
+		slog.String("webhook", webhook),
+		slog.String("user", user),
+		slog.String("operation", operation),
+		slog.Group("object", slog.String("kind", kind), slog.String("namespace", namespace), slog.String("name", name)),
+		slog.Any("reasons", reasons),
+		slog.String("message", err.Error()),
+	)
+}
+
+// reasonNone is the reason recorded for an allowed request.
+const reasonNone = "none"
+
+// listIndex matches the list indices in a field path, which would otherwise make one label value per
+// index.
+var listIndex = regexp.MustCompile(`\[[^\]]*\]`)
+
+// denialReasons returns "<field>:<cause type>" for each field error in err, with list indices
+// dropped from the field, or the status reason when err carries no field errors.
+func denialReasons(err error) []string {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return []string{"Unknown"}
+	}
+	var reasons []string
+	if details := status.Status().Details; details != nil {
+		for _, cause := range details.Causes {
+			reasons = append(reasons, listIndex.ReplaceAllString(cause.Field, "")+":"+string(cause.Type))
+		}
+	}
+	if len(reasons) == 0 {
+		reasons = append(reasons, string(status.Status().Reason))
+	}
+	return reasons
+}
+
+// fieldPrefix matches the field path the webhooks start their warnings with.
+var fieldPrefix = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9.\[\]]*): `)
+
+// warningReason returns the field a warning is about, without list indices, or "other" when it does
+// not name one.
+func warningReason(warning string) string {
+	if match := fieldPrefix.FindStringSubmatch(warning); match != nil {
+		return listIndex.ReplaceAllString(match[1], "")
+	}
+	return "other"
+}
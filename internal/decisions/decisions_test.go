@@ -0,0 +1,183 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decisions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+	"github.com/danieldanieltata/bookstore-operator/internal/metrics"
+)
+
+type stubValidator struct {
+	warnings admission.Warnings
+	err      error
+}
+
+func (s *stubValidator) ValidateCreate(context.Context, *bookstoreexamplecomv1.Book) (admission.Warnings, error) {
+	return s.warnings, s.err
+}
+
+func (s *stubValidator) ValidateUpdate(context.Context, *bookstoreexamplecomv1.Book, *bookstoreexamplecomv1.Book) (admission.Warnings, error) {
+	return s.warnings, s.err
+}
+
+func (s *stubValidator) ValidateDelete(context.Context, *bookstoreexamplecomv1.Book) (admission.Warnings, error) {
+	return s.warnings, s.err
+}
+
+type stubDefaulter struct{ err error }
+
+func (s *stubDefaulter) Default(context.Context, *bookstoreexamplecomv1.Book) error { return s.err }
+
+func asUser(operation admissionv1.Operation, user string) context.Context {
+	return admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: operation,
+			Kind:      metav1.GroupVersionKind{Group: "bookstore.example.com", Version: "v1", Kind: "Book"},
+			UserInfo:  authenticationv1.UserInfo{Username: user},
+		},
+	})
+}
+
+func decisions(webhook, operation, decision, reason string) float64 {
+	return testutil.ToFloat64(metrics.WebhookDecisions.WithLabelValues(webhook, operation, decision, reason))
+}
+
+func TestValidator_CountsDecisionsByReason(t *testing.T) {
+	const webhook = "TestValidator_CountsDecisionsByReason"
+	book := &bookstoreexamplecomv1.Book{ObjectMeta: metav1.ObjectMeta{Namespace: "tel-aviv", Name: "book"}}
+	ctx := asUser(admissionv1.Create, "alice")
+
+	if _, err := Validator(webhook, nil, &stubValidator{}).ValidateCreate(ctx, book); err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+	warn := &stubValidator{warnings: admission.Warnings{
+		`spec.genre: "Cookbooks" is not one of the known genres`,
+		"something without a field",
+	}}
+	if _, err := Validator(webhook, nil, warn).ValidateUpdate(ctx, book, book); err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+	deny := &stubValidator{err: apierrors.NewInvalid(bookstoreexamplecomv1.GroupVersion.WithKind("Book").GroupKind(), "book",
+		field.ErrorList{
+			field.Required(field.NewPath("spec", "title"), ""),
+			field.NotFound(field.NewPath("spec", "copyOf", "name"), "missing"),
+			field.Required(field.NewPath("spec", "copyPolicy", "storeSelector", "matchExpressions").Index(3).Child("values"), ""),
+		})}
+	if _, err := Validator(webhook, nil, deny).ValidateCreate(ctx, book); err == nil {
+		t.Fatal("expected the denial to be passed through")
+	}
+	forbid := &stubValidator{err: apierrors.NewForbidden(bookstoreexamplecomv1.GroupVersion.WithResource("books").GroupResource(),
+		"book", errors.New("no"))}
+	if _, err := Validator(webhook, nil, forbid).ValidateDelete(ctx, book); err == nil {
+		t.Fatal("expected the denial to be passed through")
+	}
+
+	want := map[[3]string]float64{
+		{"CREATE", metrics.DecisionAllow, "none"}:                                                                    1,
+		{"UPDATE", metrics.DecisionWarn, "spec.genre"}:                                                               1,
+		{"UPDATE", metrics.DecisionWarn, "other"}:                                                                    1,
+		{"CREATE", metrics.DecisionDeny, "spec.title:FieldValueRequired"}:                                            1,
+		{"CREATE", metrics.DecisionDeny, "spec.copyOf.name:FieldValueNotFound"}:                                      1,
+		{"CREATE", metrics.DecisionDeny, "spec.copyPolicy.storeSelector.matchExpressions.values:FieldValueRequired"}: 1,
+		{"DELETE", metrics.DecisionDeny, "Forbidden"}:                                                                1,
+	}
+	for labels, count := range want {
+		if got := decisions(webhook, labels[0], labels[1], labels[2]); got != count {
+			t.Errorf("expected %v decisions for %v, got %v", count, labels, got)
+		}
+	}
+	if got := testutil.CollectAndCount(metrics.WebhookDuration); got == 0 {
+		t.Error("expected the admission latency to be observed")
+	}
+}
+
+func TestValidator_LogsDenialsAsJSON(t *testing.T) {
+	var out bytes.Buffer
+	deny := &stubValidator{err: apierrors.NewInvalid(bookstoreexamplecomv1.GroupVersion.WithKind("Book").GroupKind(), "book",
+		field.ErrorList{field.Required(field.NewPath("spec", "price"), "")})}
+	book := &bookstoreexamplecomv1.Book{ObjectMeta: metav1.ObjectMeta{Namespace: "tel-aviv", Name: "book"}}
+	v := Validator("TestValidator_LogsDenialsAsJSON", NewDenialLog(&out), deny)
+
+	if _, err := v.ValidateUpdate(asUser(admissionv1.Update, "bob"), book, book); err == nil {
+		t.Fatal("expected the denial to be passed through")
+	}
+	if _, err := Validator("TestValidator_LogsDenialsAsJSON", NewDenialLog(&out), &stubValidator{}).
+		ValidateCreate(asUser(admissionv1.Create, "bob"), book); err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+
+	var entry struct {
+		Msg       string
+		User      string
+		Operation string
+		Object    struct{ Kind, Namespace, Name string }
+		Reasons   []string
+		Message   string
+	}
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	if len(lines) != 1 {
+		t.Fatalf("expected exactly one denial line, got %q", out.String())
+	}
+	if err := json.Unmarshal(lines[0], &entry); err != nil {
+		t.Fatalf("expected a JSON line, got %q: %v", lines[0], err)
+	}
+	if entry.User != "bob" || entry.Operation != "UPDATE" ||
+		entry.Object.Kind != "Book" || entry.Object.Namespace != "tel-aviv" || entry.Object.Name != "book" {
+		t.Errorf("unexpected denial entry: %+v", entry)
+	}
+	if len(entry.Reasons) != 1 || entry.Reasons[0] != "spec.price:FieldValueRequired" || entry.Message == "" {
+		t.Errorf("unexpected denial reasons: %+v", entry)
+	}
+}
+
+func TestDefaulter_CountsFailuresAsDenials(t *testing.T) {
+	const webhook = "TestDefaulter_CountsFailuresAsDenials"
+	var out bytes.Buffer
+	book := &bookstoreexamplecomv1.Book{ObjectMeta: metav1.ObjectMeta{Namespace: "tel-aviv", Name: "book"}}
+
+	if err := Defaulter(webhook, NewDenialLog(&out), &stubDefaulter{}).Default(asUser(admissionv1.Create, "alice"), book); err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+	if err := Defaulter(webhook, NewDenialLog(&out), &stubDefaulter{err: errors.New("lookup failed")}).
+		Default(asUser(admissionv1.Update, "alice"), book); err == nil {
+		t.Fatal("expected the failure to be passed through")
+	}
+
+	if got := decisions(webhook, "CREATE", metrics.DecisionAllow, "none"); got != 1 {
+		t.Errorf("expected one allowed create, got %v", got)
+	}
+	if got := decisions(webhook, "UPDATE", metrics.DecisionDeny, "Unknown"); got != 1 {
+		t.Errorf("expected one denied update, got %v", got)
+	}
+	if out.Len() == 0 {
+		t.Error("expected the failure in the denial log")
+	}
+}
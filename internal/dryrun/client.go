@@ -0,0 +1,121 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dryrun lets the manager reconcile a live cluster without changing it. Writes are sent to
+// the API server with dryRun=All, so they are still validated, and are logged and counted instead.
+package dryrun
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/danieldanieltata/bookstore-operator/internal/metrics"
+)
+
+// NewClient wraps c so that every write is sent with dryRun=All and recorded in the log and in
+// metrics.DryRunWrites. Reads are served by c unchanged.
+func NewClient(c client.Client) client.Client {
+	return &dryRunClient{Client: client.NewDryRunClient(c)}
+}
+
+type dryRunClient struct {
+	client.Client
+}
+
+func (c *dryRunClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	c.record(ctx, "create", obj, "")
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.record(ctx, "update", obj, "")
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.record(ctx, "patch", obj, "")
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *dryRunClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	c.record(ctx, "delete", obj, "")
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	c.record(ctx, "deletecollection", obj, "")
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	logf.FromContext(ctx).Info("Dry run: would apply", "configuration", fmt.Sprintf("%T", obj))
+	metrics.DryRunWrites.WithLabelValues("apply", "").Inc()
+	return c.Client.Apply(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *dryRunClient) SubResource(subResource string) client.SubResourceClient {
+	return &dryRunSubResourceClient{SubResourceClient: c.Client.SubResource(subResource), parent: c, name: subResource}
+}
+
+// record logs the write and counts it by verb and kind.
+func (c *dryRunClient) record(ctx context.Context, verb string, obj client.Object, subResource string) {
+	kind := fmt.Sprintf("%T", obj)
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		kind = gvk.Kind
+	}
+	logf.FromContext(ctx).Info("Dry run: would write", "verb", verb, "kind", kind,
+		"namespace", obj.GetNamespace(), "name", obj.GetName(), "subresource", subResource)
+	metrics.DryRunWrites.WithLabelValues(verb, kind).Inc()
+}
+
+type dryRunSubResourceClient struct {
+	client.SubResourceClient
+	parent *dryRunClient
+	name   string
+}
+
+func (c *dryRunSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object,
+	opts ...client.SubResourceCreateOption) error {
+	c.parent.record(ctx, "create", obj, c.name)
+	return c.SubResourceClient.Create(ctx, obj, subResource, opts...)
+}
+
+func (c *dryRunSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	c.parent.record(ctx, "update", obj, c.name)
+	return c.SubResourceClient.Update(ctx, obj, opts...)
+}
+
+func (c *dryRunSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch,
+	opts ...client.SubResourcePatchOption) error {
+	c.parent.record(ctx, "patch", obj, c.name)
+	return c.SubResourceClient.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *dryRunSubResourceClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration,
+	opts ...client.SubResourceApplyOption) error {
+	logf.FromContext(ctx).Info("Dry run: would apply", "configuration", fmt.Sprintf("%T", obj), "subresource", c.name)
+	metrics.DryRunWrites.WithLabelValues("apply", "").Inc()
+	return c.SubResourceClient.Apply(ctx, obj, opts...)
+}
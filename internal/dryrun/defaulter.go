@@ -0,0 +1,50 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Defaulter runs d on a copy of each object and only logs whether it would have changed it, so the
+// object is admitted as submitted.
+func Defaulter[T runtime.Object](d admission.Defaulter[T]) admission.Defaulter[T] {
+	return &dryRunDefaulter[T]{next: d}
+}
+
+type dryRunDefaulter[T runtime.Object] struct {
+	next admission.Defaulter[T]
+}
+
+func (d *dryRunDefaulter[T]) Default(ctx context.Context, obj T) error {
+	defaulted, ok := obj.DeepCopyObject().(T)
+	if !ok {
+		return nil
+	}
+	if err := d.next.Default(ctx, defaulted); err != nil {
+		return err
+	}
+	if !equality.Semantic.DeepEqual(obj, defaulted) {
+		logf.FromContext(ctx).Info("Dry run: would default admission request", "object", defaulted)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+	"github.com/danieldanieltata/bookstore-operator/internal/metrics"
+)
+
+func TestClient_RecordsWritesWithoutApplyingThem(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = bookstoreexamplecomv1.AddToScheme(s)
+	book := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tel-aviv", Name: "original"},
+		Spec:       bookstoreexamplecomv1.BookSpec{Title: "Orig", Price: "1", Genre: "X"},
+	}
+	underlying := fake.NewClientBuilder().WithScheme(s).WithObjects(book).
+		WithStatusSubresource(&bookstoreexamplecomv1.Book{}).Build()
+	c := NewClient(underlying)
+	ctx := context.Background()
+
+	deletes := testutil.ToFloat64(metrics.DryRunWrites.WithLabelValues("delete", "Book"))
+	statusPatches := testutil.ToFloat64(metrics.DryRunWrites.WithLabelValues("patch", "Book"))
+	creates := testutil.ToFloat64(metrics.DryRunWrites.WithLabelValues("create", "Book"))
+
+	patch := client.MergeFrom(book.DeepCopy())
+	book.Status.ReferenceCount = 3
+	if err := c.Status().Patch(ctx, book, patch); err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+	if err := c.Delete(ctx, book); err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+	if err := c.Create(ctx, &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tel-aviv", Name: "new"},
+	}); err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+
+	stored := &bookstoreexamplecomv1.Book{}
+	if err := underlying.Get(ctx, client.ObjectKeyFromObject(book), stored); err != nil {
+		t.Fatalf("expected the Book to survive the dry-run delete: %v", err)
+	}
+	if stored.Status.ReferenceCount != 0 {
+		t.Errorf("expected the dry-run status patch not to be stored, got referenceCount %d", stored.Status.ReferenceCount)
+	}
+	err := underlying.Get(ctx, client.ObjectKey{Namespace: "tel-aviv", Name: "new"}, &bookstoreexamplecomv1.Book{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the dry-run create not to be stored, got %v", err)
+	}
+
+	if got := testutil.ToFloat64(metrics.DryRunWrites.WithLabelValues("delete", "Book")) - deletes; got != 1 {
+		t.Errorf("expected 1 recorded delete, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.DryRunWrites.WithLabelValues("patch", "Book")) - statusPatches; got != 1 {
+		t.Errorf("expected 1 recorded status patch, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.DryRunWrites.WithLabelValues("create", "Book")) - creates; got != 1 {
+		t.Errorf("expected 1 recorded create, got %v", got)
+	}
+}
+
+type denyingValidator struct{}
+
+func (denyingValidator) ValidateCreate(context.Context, *bookstoreexamplecomv1.Book) (admission.Warnings, error) {
+	return admission.Warnings{"existing warning"}, errors.New("title is required")
+}
+
+func (denyingValidator) ValidateUpdate(context.Context, *bookstoreexamplecomv1.Book, *bookstoreexamplecomv1.Book) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (denyingValidator) ValidateDelete(context.Context, *bookstoreexamplecomv1.Book) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func TestValidator_WarnsInsteadOfDenying(t *testing.T) {
+	denials := testutil.ToFloat64(metrics.DryRunDenials.WithLabelValues("CREATE"))
+	v := Validator(denyingValidator{})
+
+	warnings, err := v.ValidateCreate(context.Background(), &bookstoreexamplecomv1.Book{})
+	if err != nil {
+		t.Fatalf("expected the request to be admitted, got %v", err)
+	}
+	if len(warnings) != 2 || warnings[0] != "existing warning" || !strings.Contains(warnings[1], "title is required") {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+	if got := testutil.ToFloat64(metrics.DryRunDenials.WithLabelValues("CREATE")) - denials; got != 1 {
+		t.Errorf("expected 1 recorded denial, got %v", got)
+	}
+
+	warnings, err = v.ValidateUpdate(context.Background(), &bookstoreexamplecomv1.Book{}, &bookstoreexamplecomv1.Book{})
+	if err != nil || len(warnings) != 0 {
+		t.Errorf("expected an allowed request to pass through, got %v, %v", warnings, err)
+	}
+}
+
+type titleDefaulter struct{}
+
+func (titleDefaulter) Default(_ context.Context, obj *bookstoreexamplecomv1.Book) error {
+	obj.Spec.Title = "Defaulted"
+	return nil
+}
+
+func TestDefaulter_LeavesTheObjectUnchanged(t *testing.T) {
+	book := &bookstoreexamplecomv1.Book{Spec: bookstoreexamplecomv1.BookSpec{Title: " submitted "}}
+	if err := Defaulter(titleDefaulter{}).Default(context.Background(), book); err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+	if book.Spec.Title != " submitted " {
+		t.Errorf("expected the submitted title to be kept, got %q", book.Spec.Title)
+	}
+}
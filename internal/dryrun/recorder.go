@@ -0,0 +1,46 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// NewRecorder returns an EventRecorder that logs Events instead of recording them. Events
+// describing writes that never happened would mislead anyone reading kubectl describe.
+func NewRecorder(name string) events.EventRecorder {
+	return recorder{name: name}
+}
+
+type recorder struct {
+	name string
+}
+
+func (r recorder) Eventf(regarding runtime.Object, related runtime.Object, eventtype, reason, action, note string,
+	args ...interface{}) {
+	log := logf.Log.WithName(r.name)
+	if accessor, err := meta.Accessor(regarding); err == nil {
+		log = log.WithValues("namespace", accessor.GetNamespace(), "name", accessor.GetName())
+	}
+	log.Info("Dry run: would record Event", "type", eventtype, "reason", reason, "action", action,
+		"note", fmt.Sprintf(note, args...))
+}
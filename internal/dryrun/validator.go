@@ -0,0 +1,62 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/danieldanieltata/bookstore-operator/internal/metrics"
+)
+
+// Validator admits everything v would deny, returning the denial as a warning instead, and
+// counts it in metrics.DryRunDenials.
+func Validator[T runtime.Object](v admission.Validator[T]) admission.Validator[T] {
+	return &dryRunValidator[T]{next: v}
+}
+
+type dryRunValidator[T runtime.Object] struct {
+	next admission.Validator[T]
+}
+
+func (d *dryRunValidator[T]) ValidateCreate(ctx context.Context, obj T) (admission.Warnings, error) {
+	warnings, err := d.next.ValidateCreate(ctx, obj)
+	return d.warn(ctx, "CREATE", warnings, err)
+}
+
+func (d *dryRunValidator[T]) ValidateUpdate(ctx context.Context, oldObj, newObj T) (admission.Warnings, error) {
+	warnings, err := d.next.ValidateUpdate(ctx, oldObj, newObj)
+	return d.warn(ctx, "UPDATE", warnings, err)
+}
+
+func (d *dryRunValidator[T]) ValidateDelete(ctx context.Context, obj T) (admission.Warnings, error) {
+	warnings, err := d.next.ValidateDelete(ctx, obj)
+	return d.warn(ctx, "DELETE", warnings, err)
+}
+
+func (d *dryRunValidator[T]) warn(ctx context.Context, operation string, warnings admission.Warnings,
+	err error) (admission.Warnings, error) {
+	if err == nil {
+		return warnings, nil
+	}
+	logf.FromContext(ctx).Info("Dry run: would deny admission request", "operation", operation, "reason", err.Error())
+	metrics.DryRunDenials.WithLabelValues(operation).Inc()
+	return append(warnings, "dry run: this request would be denied: "+err.Error()), nil
+}
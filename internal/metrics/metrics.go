@@ -0,0 +1,186 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics defines the bookstore domain metrics exposed next to the controller-runtime
+// defaults on the manager's metrics endpoint.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+)
+
+// Values of the decision label on WebhookDecisions and WebhookDuration.
+const (
+	DecisionAllow = "allow"
+	DecisionDeny  = "deny"
+	DecisionWarn  = "warn"
+)
+
+// Values of the kind label on DeletedBooks.
+const (
+	DeletedInStore    = "in_store"
+	DeletedRemoteCopy = "remote_copy"
+)
+
+var (
+	// CleanupDuration observes how long a BookStore's finalizer cleanup takes.
+	CleanupDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "bookstore_finalizer_cleanup_duration_seconds",
+		Help:    "Time spent deleting a BookStore's Books and their remote copies in the finalizer.",
+		Buckets: prometheus.ExponentialBuckets(0.05, 2, 12),
+	})
+
+	// DeletedBooks counts the Books deleted by finalizer cleanup, by kind.
+	DeletedBooks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bookstore_deleted_books_total",
+		Help: "Books deleted by BookStore finalizer cleanup, either in the store or remote copies of its Books.",
+	}, []string{"kind"})
+
+	// DryRunWrites counts the writes a --dry-run manager sent with dryRun=All instead of applying them.
+	DryRunWrites = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bookstore_dry_run_writes_total",
+		Help: "Writes the operator would have made in dry-run mode, by verb and kind.",
+	}, []string{"verb", "kind"})
+
+	// DryRunDenials counts the admission requests the webhook would have denied in dry-run mode.
+	DryRunDenials = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bookstore_dry_run_webhook_denials_total",
+		Help: "Admission requests the Book webhook admitted with a warning in dry-run mode instead of denying them.",
+	}, []string{"operation"})
+
+	// CopyOfLookupDuration observes the webhook's API server lookups of a copy's original, by result.
+	CopyOfLookupDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "bookstore_webhook_copyof_lookup_duration_seconds",
+		Help:    "Time the Book webhook spent looking up spec.copyOf on the API server, by result (found, not_found, error).",
+		Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"result"})
+
+	// WebhookDecisions counts the admission decisions of the webhooks. A denial counts once per field
+	// error and a warning once per warning, so the reasons add up to more than the requests.
+	WebhookDecisions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bookstore_webhook_admission_decisions_total",
+		Help: "Admission decisions of the bookstore webhooks, by webhook, operation, decision (allow, deny, warn) and reason.",
+	}, []string{"webhook", "operation", "decision", "reason"})
+
+	// WebhookDuration observes how long each admission request took, by its overall decision.
+	WebhookDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "bookstore_webhook_admission_duration_seconds",
+		Help:    "Time the bookstore webhooks spent on an admission request, by webhook, operation and decision.",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{"webhook", "operation", "decision"})
+
+	// ConsistencyFindings reports the inconsistencies found by the latest consistency audit, by type.
+	ConsistencyFindings = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "bookstore_consistency_findings",
+		Help: "Inconsistencies found by the latest consistency audit run, by finding type.",
+	}, []string{"type"})
+)
+
+func init() {
+	crmetrics.Registry.MustRegister(CleanupDuration, DeletedBooks, DryRunWrites, DryRunDenials, CopyOfLookupDuration,
+		WebhookDecisions, WebhookDuration, ConsistencyFindings)
+}
+
+// collectTimeout bounds the Book list made on every scrape.
+const collectTimeout = 10 * time.Second
+
+// referenceCountBuckets are the upper bounds of the referenceCount histogram.
+var referenceCountBuckets = []float64{0, 1, 2, 5, 10, 25, 50, 100}
+
+var (
+	booksDesc = prometheus.NewDesc("bookstore_books",
+		"Books per store namespace.", []string{"store"}, nil)
+	booksByKindDesc = prometheus.NewDesc("bookstore_books_by_kind",
+		"Books by kind, original or copy.", []string{"kind"}, nil)
+	referenceCountDesc = prometheus.NewDesc("bookstore_book_reference_count",
+		"Distribution of status.referenceCount across original Books.", nil, nil)
+	crossStoreCopiesDesc = prometheus.NewDesc("bookstore_cross_store_copies",
+		"Copies whose original lives in another store, by source and target store.", []string{"source_store", "target_store"}, nil)
+)
+
+// InventoryCollector reports the Book inventory on every scrape. It reads from the manager's cache,
+// so the gauges always match what is stored and no controller has to keep them up to date.
+type InventoryCollector struct {
+	reader client.Reader
+}
+
+// NewInventoryCollector returns a collector that lists Books through reader.
+func NewInventoryCollector(reader client.Reader) *InventoryCollector {
+	return &InventoryCollector{reader: reader}
+}
+
+// Describe implements prometheus.Collector.
+func (c *InventoryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- booksDesc
+	ch <- booksByKindDesc
+	ch <- referenceCountDesc
+	ch <- crossStoreCopiesDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *InventoryCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	var books bookstoreexamplecomv1.BookList
+	if err := c.reader.List(ctx, &books); err != nil {
+		logf.Log.WithName("metrics").Error(err, "Failed to list Books for inventory metrics")
+		return
+	}
+
+	type edge struct{ source, target string }
+	perStore := map[string]int{}
+	crossStore := map[edge]int{}
+	originals, copies := 0, 0
+	var referenceSum float64
+	referenceBuckets := make(map[float64]uint64, len(referenceCountBuckets))
+
+	for _, book := range books.Items {
+		perStore[book.Namespace]++
+		if book.Spec.CopyOf != nil {
+			copies++
+			if book.Spec.CopyOf.Namespace != book.Namespace {
+				crossStore[edge{source: book.Spec.CopyOf.Namespace, target: book.Namespace}]++
+			}
+			continue
+		}
+		originals++
+		referenceSum += float64(book.Status.ReferenceCount)
+		for _, bound := range referenceCountBuckets {
+			if float64(book.Status.ReferenceCount) <= bound {
+				referenceBuckets[bound]++
+			}
+		}
+	}
+
+	for store, count := range perStore {
+		ch <- prometheus.MustNewConstMetric(booksDesc, prometheus.GaugeValue, float64(count), store)
+	}
+	ch <- prometheus.MustNewConstMetric(booksByKindDesc, prometheus.GaugeValue, float64(originals), "original")
+	ch <- prometheus.MustNewConstMetric(booksByKindDesc, prometheus.GaugeValue, float64(copies), "copy")
+	ch <- prometheus.MustNewConstHistogram(referenceCountDesc, uint64(originals), referenceSum, referenceBuckets)
+	for e, count := range crossStore {
+		ch <- prometheus.MustNewConstMetric(crossStoreCopiesDesc, prometheus.GaugeValue, float64(count), e.source, e.target)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+)
+
+func TestInventoryCollector(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = bookstoreexamplecomv1.AddToScheme(s)
+
+	original := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tel-aviv", Name: "original"},
+		Spec:       bookstoreexamplecomv1.BookSpec{Title: "Orig", Price: "1", Genre: "X"},
+		Status:     bookstoreexamplecomv1.BookStatus{ReferenceCount: 2},
+	}
+	lonely := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tel-aviv", Name: "lonely"},
+		Spec:       bookstoreexamplecomv1.BookSpec{Title: "Lonely", Price: "1", Genre: "X"},
+	}
+	localCopy := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tel-aviv", Name: "local-copy"},
+		Spec: bookstoreexamplecomv1.BookSpec{
+			Title:  "Local",
+			CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: "tel-aviv", Name: "original"},
+		},
+	}
+	remoteCopy := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "jerusalem", Name: "remote-copy"},
+		Spec: bookstoreexamplecomv1.BookSpec{
+			Title:  "Remote",
+			CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: "tel-aviv", Name: "original"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(original, lonely, localCopy, remoteCopy).Build()
+
+	expected := `
+# HELP bookstore_book_reference_count Distribution of status.referenceCount across original Books.
+# TYPE bookstore_book_reference_count histogram
+bookstore_book_reference_count_bucket{le="0"} 1
+bookstore_book_reference_count_bucket{le="1"} 1
+bookstore_book_reference_count_bucket{le="2"} 2
+bookstore_book_reference_count_bucket{le="5"} 2
+bookstore_book_reference_count_bucket{le="10"} 2
+bookstore_book_reference_count_bucket{le="25"} 2
+bookstore_book_reference_count_bucket{le="50"} 2
+bookstore_book_reference_count_bucket{le="100"} 2
+bookstore_book_reference_count_bucket{le="+Inf"} 2
+bookstore_book_reference_count_sum 2
+bookstore_book_reference_count_count 2
+# HELP bookstore_books Books per store namespace.
+# TYPE bookstore_books gauge
+bookstore_books{store="jerusalem"} 1
+bookstore_books{store="tel-aviv"} 3
+# HELP bookstore_books_by_kind Books by kind, original or copy.
+# TYPE bookstore_books_by_kind gauge
+bookstore_books_by_kind{kind="copy"} 2
+bookstore_books_by_kind{kind="original"} 2
+# HELP bookstore_cross_store_copies Copies whose original lives in another store, by source and target store.
+# TYPE bookstore_cross_store_copies gauge
+bookstore_cross_store_copies{source_store="tel-aviv",target_store="jerusalem"} 1
+`
+	if err := testutil.CollectAndCompare(NewInventoryCollector(c), strings.NewReader(expected)); err != nil {
+		t.Fatal(err)
+	}
+}
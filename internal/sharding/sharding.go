@@ -0,0 +1,119 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sharding splits BookStores, and the Books in their namespaces, across operator replicas.
+// Every replica still caches all Books, so an original's owner counts copies held by any shard.
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+	"github.com/danieldanieltata/bookstore-operator/internal/stores"
+)
+
+// Sharder decides which BookStores a replica reconciles. The zero value owns everything.
+type Sharder struct {
+	// Shard is the index of this replica's shard, from 0 to Count-1.
+	Shard int
+	// Count is the total number of shards. Zero or one disables sharding.
+	Count int
+}
+
+// Validate checks that Shard is within Count.
+func (s Sharder) Validate() error {
+	if s.Count < 0 {
+		return fmt.Errorf("shard count must not be negative, got %d", s.Count)
+	}
+	if s.Enabled() && (s.Shard < 0 || s.Shard >= s.Count) {
+		return fmt.Errorf("shard must be between 0 and %d, got %d", s.Count-1, s.Shard)
+	}
+	return nil
+}
+
+// Enabled reports whether reconciliation is split across more than one shard.
+func (s Sharder) Enabled() bool {
+	return s.Count > 1
+}
+
+// LeaderElectionID returns the lease name for this replica's shard, so each shard elects its own
+// leader. It returns id unchanged when sharding is disabled.
+func (s Sharder) LeaderElectionID(id string) string {
+	if !s.Enabled() {
+		return id
+	}
+	return fmt.Sprintf("shard-%d-of-%d.%s", s.Shard, s.Count, id)
+}
+
+// ShardFor returns the shard of a BookStore: its ShardLabel when that holds a valid index, or
+// else a consistent hash of its name.
+func (s Sharder) ShardFor(bookstore *bookstoreexamplecomv1.BookStore) int {
+	if !s.Enabled() {
+		return 0
+	}
+	if value, ok := bookstore.Labels[bookstoreexamplecomv1.ShardLabel]; ok {
+		if shard, err := strconv.Atoi(value); err == nil && shard >= 0 && shard < s.Count {
+			return shard
+		}
+	}
+	return s.shardForName(bookstore.Name)
+}
+
+// OwnsStore reports whether this replica reconciles bookstore.
+func (s Sharder) OwnsStore(bookstore *bookstoreexamplecomv1.BookStore) bool {
+	return !s.Enabled() || s.ShardFor(bookstore) == s.Shard
+}
+
+// OwnsNamespace reports whether this replica reconciles the Books in namespace. They follow the
+// store named after the namespace, and a namespace without a store is assigned by its name.
+func (s Sharder) OwnsNamespace(ctx context.Context, c client.Reader, namespace string) (bool, error) {
+	if !s.Enabled() {
+		return true, nil
+	}
+	bookstore, err := stores.ForNamespace(ctx, c, namespace)
+	if err != nil {
+		return false, err
+	}
+	if bookstore == nil {
+		return s.shardForName(namespace) == s.Shard, nil
+	}
+	return s.OwnsStore(bookstore), nil
+}
+
+// shardForName hashes name onto a shard with jump consistent hashing, so changing the shard
+// count only moves about 1/Count of the stores.
+func (s Sharder) shardForName(name string) int {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return jumpHash(h.Sum64(), s.Count)
+}
+
+// jumpHash is the jump consistent hash from Lamping and Veach, "A Fast, Minimal Memory,
+// Consistent Hash Algorithm".
+func jumpHash(key uint64, buckets int) int {
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+	"github.com/danieldanieltata/bookstore-operator/internal/stores"
+)
+
+func store(name string, labels map[string]string) *bookstoreexamplecomv1.BookStore {
+	return &bookstoreexamplecomv1.BookStore{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+	}
+}
+
+func TestOwnsStore_EachStoreHasExactlyOneOwner(t *testing.T) {
+	const count = 4
+	perShard := make([]int, count)
+	for i := range 400 {
+		bookstore := store(fmt.Sprintf("store-%d", i), nil)
+		owners := 0
+		for shard := range count {
+			if (Sharder{Shard: shard, Count: count}).OwnsStore(bookstore) {
+				owners++
+				perShard[shard]++
+			}
+		}
+		if owners != 1 {
+			t.Fatalf("store %s has %d owners", bookstore.Name, owners)
+		}
+	}
+	for shard, n := range perShard {
+		if n < 50 {
+			t.Errorf("shard %d only owns %d of 400 stores", shard, n)
+		}
+	}
+}
+
+func TestShardFor_LabelOverridesHash(t *testing.T) {
+	sharder := Sharder{Shard: 0, Count: 3}
+
+	for _, value := range []string{"0", "1", "2"} {
+		bookstore := store("pinned", map[string]string{bookstoreexamplecomv1.ShardLabel: value})
+		if got := fmt.Sprint(sharder.ShardFor(bookstore)); got != value {
+			t.Errorf("expected shard %s from the label, got %s", value, got)
+		}
+	}
+
+	hashed := sharder.ShardFor(store("pinned", nil))
+	for _, value := range []string{"3", "-1", "two"} {
+		bookstore := store("pinned", map[string]string{bookstoreexamplecomv1.ShardLabel: value})
+		if got := sharder.ShardFor(bookstore); got != hashed {
+			t.Errorf("expected label %q to fall back to the hashed shard %d, got %d", value, hashed, got)
+		}
+	}
+}
+
+func TestShardFor_GrowingMovesFewStores(t *testing.T) {
+	moved := 0
+	for i := range 1000 {
+		bookstore := store(fmt.Sprintf("store-%d", i), nil)
+		if (Sharder{Count: 4}).ShardFor(bookstore) != (Sharder{Count: 5}).ShardFor(bookstore) {
+			moved++
+		}
+	}
+	// Consistent hashing moves about a fifth of the stores, a modulo hash about four fifths.
+	if moved > 300 {
+		t.Errorf("going from 4 to 5 shards moved %d of 1000 stores", moved)
+	}
+}
+
+func TestOwnsNamespace_FollowsStoreLabel(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := bookstoreexamplecomv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(store("pinned", map[string]string{bookstoreexamplecomv1.ShardLabel: "1"})).
+		WithIndex(&bookstoreexamplecomv1.BookStore{}, stores.NameField, stores.IndexName).
+		Build()
+
+	for shard, want := range []bool{false, true} {
+		got, err := Sharder{Shard: shard, Count: 2}.OwnsNamespace(context.Background(), c, "pinned")
+		if err != nil {
+			t.Fatalf("expected no error: %v", err)
+		}
+		if got != want {
+			t.Errorf("shard %d: expected owns=%t, got %t", shard, want, got)
+		}
+	}
+
+	owners := 0
+	for shard := range 2 {
+		owned, err := Sharder{Shard: shard, Count: 2}.OwnsNamespace(context.Background(), c, "no-store")
+		if err != nil {
+			t.Fatalf("expected no error: %v", err)
+		}
+		if owned {
+			owners++
+		}
+	}
+	if owners != 1 {
+		t.Errorf("expected a namespace without a store to have one owner, got %d", owners)
+	}
+}
+
+func TestSharder_DisabledOwnsEverything(t *testing.T) {
+	var sharder Sharder
+	if !sharder.OwnsStore(store("any", map[string]string{bookstoreexamplecomv1.ShardLabel: "3"})) {
+		t.Error("expected the zero Sharder to own every store")
+	}
+	if got := sharder.LeaderElectionID("id.example.com"); got != "id.example.com" {
+		t.Errorf("expected the lease name to be unchanged, got %q", got)
+	}
+	if got := (Sharder{Shard: 1, Count: 3}).LeaderElectionID("id.example.com"); got != "shard-1-of-3.id.example.com" {
+		t.Errorf("unexpected shard lease name %q", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, sharder := range []Sharder{{Shard: 3, Count: 3}, {Shard: -1, Count: 2}, {Count: -1}} {
+		if err := sharder.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", sharder)
+		}
+	}
+	if err := (Sharder{Shard: 2, Count: 3}).Validate(); err != nil {
+		t.Errorf("expected no error: %v", err)
+	}
+}
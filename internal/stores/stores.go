@@ -0,0 +1,143 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stores holds lookups shared by the controllers and webhooks that need to know
+// which BookStore a namespace belongs to.
+package stores
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+)
+
+// Fields the lookups in this package select on. The API server serves both, a client reading from
+// the manager cache needs them registered with IndexFields first.
+const (
+	// NameField selects BookStores by name, which is also the name of their namespace.
+	NameField = "metadata.name"
+	// CopyOfNamespaceField selects Books by spec.copyOf.namespace, the store their original lives in.
+	CopyOfNamespaceField = "spec.copyOf.namespace"
+)
+
+// IndexFields registers the indexes behind NameField and CopyOfNamespaceField.
+func IndexFields(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &bookstoreexamplecomv1.BookStore{}, NameField, IndexName); err != nil {
+		return err
+	}
+	return indexer.IndexField(ctx, &bookstoreexamplecomv1.Book{}, CopyOfNamespaceField, IndexCopyOfNamespace)
+}
+
+// IndexName is the indexer for NameField.
+func IndexName(obj client.Object) []string {
+	return []string{obj.GetName()}
+}
+
+// IndexCopyOfNamespace is the indexer for CopyOfNamespaceField.
+func IndexCopyOfNamespace(obj client.Object) []string {
+	book, ok := obj.(*bookstoreexamplecomv1.Book)
+	if !ok || book.Spec.CopyOf == nil || book.Spec.CopyOf.Namespace == "" {
+		return nil
+	}
+	return []string{book.Spec.CopyOf.Namespace}
+}
+
+// ForNamespace returns the BookStore that manages namespace, or nil when there is none.
+// A store's namespace has the same name as the store, so any BookStore with that name owns it.
+func ForNamespace(ctx context.Context, c client.Reader, namespace string) (*bookstoreexamplecomv1.BookStore, error) {
+	var bookstores bookstoreexamplecomv1.BookStoreList
+	if err := c.List(ctx, &bookstores, client.MatchingFields{NameField: namespace}); err != nil {
+		return nil, err
+	}
+	if len(bookstores.Items) == 0 {
+		return nil, nil
+	}
+	return &bookstores.Items[0], nil
+}
+
+// Copies returns the Books in every namespace whose spec.copyOf points into namespace.
+func Copies(ctx context.Context, c client.Reader, namespace string) ([]bookstoreexamplecomv1.Book, error) {
+	var books bookstoreexamplecomv1.BookList
+	if err := c.List(ctx, &books, client.MatchingFields{CopyOfNamespaceField: namespace}); err != nil {
+		return nil, err
+	}
+	return books.Items, nil
+}
+
+// AllowsCopy reports whether source's copy policy lets target hold copies of source's Books. A store
+// may always copy its own Books. target is nil for a namespace without a store, which only an
+// AllowAll policy admits.
+func AllowsCopy(source, target *bookstoreexamplecomv1.BookStore) (bool, error) {
+	policy := source.Spec.CopyPolicy
+	if policy == nil {
+		return true, nil
+	}
+	if target != nil && target.Name == source.Name {
+		return true, nil
+	}
+	switch policy.Type {
+	case "", bookstoreexamplecomv1.CopyPolicyAllowAll:
+		return true, nil
+	case bookstoreexamplecomv1.CopyPolicyDenyAll:
+		return false, nil
+	case bookstoreexamplecomv1.CopyPolicySelector:
+		if target == nil || policy.StoreSelector == nil {
+			return false, nil
+		}
+		selector, err := metav1.LabelSelectorAsSelector(policy.StoreSelector)
+		if err != nil {
+			return false, fmt.Errorf("invalid copyPolicy.storeSelector on BookStore %q: %w", source.Name, err)
+		}
+		return selector.Matches(labels.Set(target.Labels)), nil
+	default:
+		return false, fmt.Errorf("unknown copyPolicy.type %q on BookStore %q", policy.Type, source.Name)
+	}
+}
+
+// CopyHolders returns, sorted, the other stores holding copies of bookstore's Books. A store's
+// namespace has the same name as the store, so the holders are the store-managed namespaces with such
+// copies.
+func CopyHolders(ctx context.Context, c client.Reader, bookstore *bookstoreexamplecomv1.BookStore) ([]string, error) {
+	copies, err := Copies(ctx, c, bookstore.Name)
+	if err != nil {
+		return nil, err
+	}
+	var namespaces []string
+	for _, book := range copies {
+		if book.Namespace != bookstore.Name {
+			namespaces = append(namespaces, book.Namespace)
+		}
+	}
+	slices.Sort(namespaces)
+
+	var holders []string
+	for _, namespace := range slices.Compact(namespaces) {
+		store, err := ForNamespace(ctx, c, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if store != nil {
+			holders = append(holders, namespace)
+		}
+	}
+	return holders, nil
+}
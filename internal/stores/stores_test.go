@@ -0,0 +1,117 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stores
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+)
+
+func TestAllowsCopy(t *testing.T) {
+	store := func(name string, labels map[string]string, policy *bookstoreexamplecomv1.CopyPolicy) *bookstoreexamplecomv1.BookStore {
+		return &bookstoreexamplecomv1.BookStore{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec:       bookstoreexamplecomv1.BookStoreSpec{CopyPolicy: policy},
+		}
+	}
+	flagshipOnly := &bookstoreexamplecomv1.CopyPolicy{
+		Type:          bookstoreexamplecomv1.CopyPolicySelector,
+		StoreSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "flagship"}},
+	}
+	denyAll := &bookstoreexamplecomv1.CopyPolicy{Type: bookstoreexamplecomv1.CopyPolicyDenyAll}
+	flagship := store("hadera", map[string]string{"tier": "flagship"}, nil)
+	outlet := store("eilat", nil, nil)
+
+	cases := map[string]struct {
+		source, target *bookstoreexamplecomv1.BookStore
+		want           bool
+	}{
+		"no policy":                     {store("tel-aviv", nil, nil), outlet, true},
+		"allow all":                     {store("tel-aviv", nil, &bookstoreexamplecomv1.CopyPolicy{Type: bookstoreexamplecomv1.CopyPolicyAllowAll}), nil, true},
+		"deny all":                      {store("tel-aviv", nil, denyAll), flagship, false},
+		"deny all within the store":     {store("tel-aviv", nil, denyAll), store("tel-aviv", nil, nil), true},
+		"selector matches":              {store("tel-aviv", nil, flagshipOnly), flagship, true},
+		"selector does not match":       {store("tel-aviv", nil, flagshipOnly), outlet, false},
+		"selector and unmanaged target": {store("tel-aviv", nil, flagshipOnly), nil, false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := AllowsCopy(tc.source, tc.target)
+			if err != nil {
+				t.Fatalf("expected no error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	t.Run("invalid selector", func(t *testing.T) {
+		bad := store("tel-aviv", nil, &bookstoreexamplecomv1.CopyPolicy{
+			Type: bookstoreexamplecomv1.CopyPolicySelector,
+			StoreSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: "Near"},
+			}},
+		})
+		if _, err := AllowsCopy(bad, flagship); err == nil {
+			t.Error("expected an error for an invalid selector")
+		}
+	})
+}
+
+func TestCopyHolders(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = bookstoreexamplecomv1.AddToScheme(scheme)
+	copyIn := func(namespace, name string) *bookstoreexamplecomv1.Book {
+		return &bookstoreexamplecomv1.Book{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: bookstoreexamplecomv1.BookSpec{
+				Title:  name,
+				CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: "source", Name: "original"},
+			},
+		}
+	}
+	source := &bookstoreexamplecomv1.BookStore{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "source"}}
+	elsewhere := copyIn("c", "elsewhere")
+	elsewhere.Spec.CopyOf.Namespace = "other"
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		source,
+		&bookstoreexamplecomv1.BookStore{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "b"}},
+		&bookstoreexamplecomv1.BookStore{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}},
+		&bookstoreexamplecomv1.BookStore{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "c"}},
+		copyIn("b", "first"), copyIn("b", "second"), copyIn("a", "third"),
+		copyIn("source", "own"), copyIn("unmanaged", "stray"), elsewhere,
+	).
+		WithIndex(&bookstoreexamplecomv1.BookStore{}, NameField, IndexName).
+		WithIndex(&bookstoreexamplecomv1.Book{}, CopyOfNamespaceField, IndexCopyOfNamespace).
+		Build()
+
+	holders, err := CopyHolders(context.Background(), c, source)
+	if err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+	if want := []string{"a", "b"}; !slices.Equal(holders, want) {
+		t.Errorf("expected holders %v, got %v", want, holders)
+	}
+}
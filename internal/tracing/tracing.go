@@ -0,0 +1,231 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing wires OpenTelemetry spans through the reconcilers, the admission webhooks and
+// the requests they make to the API server.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const serviceName = "bookstore-operator"
+
+const instrumentationName = "github.com/danieldanieltata/bookstore-operator"
+
+// tracer is looked up on every span so it always follows the current global provider.
+// Spans started before Setup, or with tracing disabled, are no-ops.
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Options configures where spans are exported. With neither exporter set tracing stays disabled.
+type Options struct {
+	// OTLPEndpoint is the host:port of an OTLP gRPC collector.
+	OTLPEndpoint string
+	// OTLPInsecure disables TLS towards the collector.
+	OTLPInsecure bool
+	// File receives spans as JSON, "-" writes them to stdout. Meant for local debugging.
+	File string
+	// SampleRatio is the fraction of new traces that are recorded.
+	SampleRatio float64
+}
+
+// Setup installs the global tracer provider and returns a function that flushes and stops it.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	if opts.OTLPEndpoint == "" && opts.File == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	providerOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	}
+	var closers []io.Closer
+
+	if opts.OTLPEndpoint != "" {
+		clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.OTLPEndpoint)}
+		if opts.OTLPInsecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(ctx, clientOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		}
+		providerOpts = append(providerOpts, sdktrace.WithBatcher(exporter))
+	}
+
+	if opts.File != "" {
+		var out io.Writer = os.Stdout
+		if opts.File != "-" {
+			f, err := os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open trace file: %w", err)
+			}
+			out = f
+			closers = append(closers, f)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(out))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create file exporter: %w", err)
+		}
+		providerOpts = append(providerOpts, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(providerOpts...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		for _, c := range closers {
+			err = errors.Join(err, c.Close())
+		}
+		return err
+	}, nil
+}
+
+// WrapConfig makes every request sent through cfg a child span of the span in its context.
+// Requests made outside a span, like the cache's list and watch calls, are not traced.
+func WrapConfig(cfg *rest.Config) {
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return otelhttp.NewTransport(rt,
+			otelhttp.WithFilter(func(r *http.Request) bool {
+				return trace.SpanContextFromContext(r.Context()).IsValid()
+			}),
+			otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+				return r.Method + " " + r.URL.Path
+			}),
+		)
+	})
+}
+
+// Reconciler runs each Reconcile call of r in its own span named after the controller.
+// The trace ID is added to the request logger so log lines can be matched to the trace.
+func Reconciler(name string, r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		ctx, span := tracer().Start(ctx, name+".Reconcile", trace.WithAttributes(
+			attribute.String("k8s.namespace", req.Namespace),
+			attribute.String("k8s.name", req.Name),
+		))
+		defer span.End()
+		if span.SpanContext().IsValid() {
+			ctx = logf.IntoContext(ctx, logf.FromContext(ctx).WithValues("traceID", span.SpanContext().TraceID().String()))
+		}
+
+		result, err := r.Reconcile(ctx, req)
+		recordError(span, err)
+		return result, err
+	})
+}
+
+// Validator runs each method of v in its own span named after the validator.
+func Validator[T runtime.Object](name string, v admission.Validator[T]) admission.Validator[T] {
+	return &tracedValidator[T]{name: name, next: v}
+}
+
+type tracedValidator[T runtime.Object] struct {
+	name string
+	next admission.Validator[T]
+}
+
+func (t *tracedValidator[T]) ValidateCreate(ctx context.Context, obj T) (admission.Warnings, error) {
+	ctx, span := t.start(ctx, "ValidateCreate")
+	defer span.End()
+	warnings, err := t.next.ValidateCreate(ctx, obj)
+	recordError(span, err)
+	return warnings, err
+}
+
+func (t *tracedValidator[T]) ValidateUpdate(ctx context.Context, oldObj, newObj T) (admission.Warnings, error) {
+	ctx, span := t.start(ctx, "ValidateUpdate")
+	defer span.End()
+	warnings, err := t.next.ValidateUpdate(ctx, oldObj, newObj)
+	recordError(span, err)
+	return warnings, err
+}
+
+func (t *tracedValidator[T]) ValidateDelete(ctx context.Context, obj T) (admission.Warnings, error) {
+	ctx, span := t.start(ctx, "ValidateDelete")
+	defer span.End()
+	warnings, err := t.next.ValidateDelete(ctx, obj)
+	recordError(span, err)
+	return warnings, err
+}
+
+func (t *tracedValidator[T]) start(ctx context.Context, method string) (context.Context, trace.Span) {
+	return startAdmission(ctx, t.name+"."+method)
+}
+
+// Defaulter runs d in a span named after the defaulter.
+func Defaulter[T runtime.Object](name string, d admission.Defaulter[T]) admission.Defaulter[T] {
+	return &tracedDefaulter[T]{name: name, next: d}
+}
+
+type tracedDefaulter[T runtime.Object] struct {
+	name string
+	next admission.Defaulter[T]
+}
+
+func (t *tracedDefaulter[T]) Default(ctx context.Context, obj T) error {
+	ctx, span := startAdmission(ctx, t.name+".Default")
+	defer span.End()
+	err := t.next.Default(ctx, obj)
+	recordError(span, err)
+	return err
+}
+
+func startAdmission(ctx context.Context, spanName string) (context.Context, trace.Span) {
+	var attrs []attribute.KeyValue
+	if req, err := admission.RequestFromContext(ctx); err == nil {
+		attrs = append(attrs,
+			attribute.String("k8s.namespace", req.Namespace),
+			attribute.String("k8s.name", req.Name),
+			attribute.String("admission.operation", string(req.Operation)),
+			attribute.String("admission.user", req.UserInfo.Username),
+		)
+	}
+	return tracer().Start(ctx, spanName, trace.WithAttributes(attrs...))
+}
+
+func recordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
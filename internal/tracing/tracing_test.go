@@ -0,0 +1,129 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestReconciler_RecordsSpanAndError(t *testing.T) {
+	recorder := recordSpans(t)
+
+	wrapped := Reconciler("BookReconciler", reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+		return reconcile.Result{}, errors.New("boom")
+	}))
+	_, err := wrapped.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Namespace: "tel-aviv", Name: "book"},
+	})
+	if err == nil {
+		t.Fatal("expected the reconciler error to be returned")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if name := spans[0].Name(); name != "BookReconciler.Reconcile" {
+		t.Errorf("unexpected span name: %s", name)
+	}
+	if status := spans[0].Status(); status.Code != codes.Error || status.Description != "boom" {
+		t.Errorf("unexpected span status: %+v", status)
+	}
+}
+
+type allowAll struct{}
+
+func (allowAll) ValidateCreate(context.Context, *bookstoreexamplecomv1.Book) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (allowAll) ValidateUpdate(context.Context, *bookstoreexamplecomv1.Book, *bookstoreexamplecomv1.Book) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (allowAll) ValidateDelete(context.Context, *bookstoreexamplecomv1.Book) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func TestValidator_RecordsSpanPerMethod(t *testing.T) {
+	recorder := recordSpans(t)
+
+	v := Validator[*bookstoreexamplecomv1.Book]("BookCustomValidator", allowAll{})
+	book := &bookstoreexamplecomv1.Book{}
+	_, _ = v.ValidateCreate(context.Background(), book)
+	_, _ = v.ValidateUpdate(context.Background(), book, book)
+	_, _ = v.ValidateDelete(context.Background(), book)
+
+	var names []string
+	for _, span := range recorder.Ended() {
+		names = append(names, span.Name())
+	}
+	want := []string{
+		"BookCustomValidator.ValidateCreate",
+		"BookCustomValidator.ValidateUpdate",
+		"BookCustomValidator.ValidateDelete",
+	}
+	if len(names) != len(want) {
+		t.Fatalf("expected spans %v, got %v", want, names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("expected span %q, got %q", want[i], names[i])
+		}
+	}
+}
+
+type noopDefaulter struct{}
+
+func (noopDefaulter) Default(context.Context, *bookstoreexamplecomv1.Book) error {
+	return nil
+}
+
+func TestDefaulter_RecordsSpan(t *testing.T) {
+	recorder := recordSpans(t)
+
+	d := Defaulter[*bookstoreexamplecomv1.Book]("BookCustomDefaulter", noopDefaulter{})
+	if err := d.Default(context.Background(), &bookstoreexamplecomv1.Book{}); err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Name() != "BookCustomDefaulter.Default" {
+		t.Errorf("expected a BookCustomDefaulter.Default span, got %v", spans)
+	}
+}
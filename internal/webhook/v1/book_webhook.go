@@ -18,31 +18,240 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"math/big"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
 
+	admissionv1 "k8s.io/api/admission/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+	"github.com/danieldanieltata/bookstore-operator/internal/config"
+	"github.com/danieldanieltata/bookstore-operator/internal/decisions"
+	"github.com/danieldanieltata/bookstore-operator/internal/dryrun"
+	"github.com/danieldanieltata/bookstore-operator/internal/metrics"
+	"github.com/danieldanieltata/bookstore-operator/internal/stores"
+	"github.com/danieldanieltata/bookstore-operator/internal/tracing"
 )
 
 // nolint:unused
 // log is for logging in this package.
 var booklog = logf.Log.WithName("book-resource")
 
+// BookWebhookOptions configures the Book webhooks.
+type BookWebhookOptions struct {
+	// AllowUnmanagedNamespaces admits Books in namespaces that no BookStore manages.
+	AllowUnmanagedNamespaces bool
+	// Config supplies the toggles that can change while the manager runs.
+	Config *config.Watcher
+	// DryRun admits requests the validator would deny and returns the denial as a warning.
+	DryRun bool
+	// DenialLog receives every denied request as a JSON line. Nil turns it off.
+	DenialLog *slog.Logger
+}
+
 // SetupBookWebhookWithManager registers the webhook for Book in the manager.
-func SetupBookWebhookWithManager(mgr ctrl.Manager) error {
+func SetupBookWebhookWithManager(mgr ctrl.Manager, opts BookWebhookOptions) error {
+	var defaulter admission.Defaulter[*bookstoreexamplecomv1.Book] = &BookCustomDefaulter{
+		Client: mgr.GetClient(),
+	}
+	var validator admission.Validator[*bookstoreexamplecomv1.Book] = &BookCustomValidator{
+		Client:                   mgr.GetClient(),
+		APIReader:                mgr.GetAPIReader(),
+		AllowUnmanagedNamespaces: opts.AllowUnmanagedNamespaces,
+		Config:                   opts.Config,
+	}
+	if opts.DryRun {
+		defaulter = dryrun.Defaulter(defaulter)
+		validator = dryrun.Validator(validator)
+	}
+	defaulter = decisions.Defaulter("BookCustomDefaulter", opts.DenialLog, defaulter)
+	validator = decisions.Validator("BookCustomValidator", opts.DenialLog, validator)
 	return ctrl.NewWebhookManagedBy(mgr, &bookstoreexamplecomv1.Book{}).
-		WithValidator(&BookCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(tracing.Defaulter("BookCustomDefaulter", defaulter)).
+		WithValidator(tracing.Validator("BookCustomValidator", validator)).
 		Complete()
 }
 
 // TODO(user): EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 
+// +kubebuilder:webhook:path=/mutate-bookstore-example-com-v1-book,mutating=true,failurePolicy=fail,sideEffects=None,groups=bookstore.example.com,resources=books,verbs=create;update,versions=v1,name=mbook-v1.kb.io,admissionReviewVersions=v1
+
+// BookCustomDefaulter normalizes the spec of a Book and sets the labels and annotations that
+// describe it, so Books can be selected by store, by type and by original.
+type BookCustomDefaulter struct {
+	Client client.Client
+}
+
+// pricePattern matches the prices the defaulter rewrites to two decimal places. Anything else is
+// left for the validator to judge.
+var pricePattern = regexp.MustCompile(`^\$?\s*[0-9]+(\.[0-9]+)?$`)
+
+// Default implements admission.Defaulter so a webhook will be registered for the type Book.
+func (d *BookCustomDefaulter) Default(ctx context.Context, obj *bookstoreexamplecomv1.Book) error {
+	booklog.Info("Defaulting for Book", "name", obj.GetName())
+
+	obj.Spec.Title = normalizeTitle(obj.Spec.Title)
+	obj.Spec.Genre = normalizeGenre(obj.Spec.Genre)
+	obj.Spec.Price = normalizePrice(obj.Spec.Price)
+
+	bookstore, err := stores.ForNamespace(ctx, d.Client, obj.GetNamespace())
+	if err != nil {
+		return fmt.Errorf("failed to look up the BookStore for namespace %q: %w", obj.GetNamespace(), err)
+	}
+
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	if bookstore != nil {
+		labels[bookstoreexamplecomv1.BookStoreLabel] = bookstore.Name
+	} else {
+		delete(labels, bookstoreexamplecomv1.BookStoreLabel)
+	}
+	annotations := obj.GetAnnotations()
+	if copyOf := obj.Spec.CopyOf; copyOf != nil {
+		labels[bookstoreexamplecomv1.BookTypeLabel] = bookstoreexamplecomv1.BookTypeCopy
+		labels[bookstoreexamplecomv1.OriginalRefLabel] = bookstoreexamplecomv1.OriginalRefHash(copyOf.Namespace, copyOf.Name)
+		if inherited := inheritedFields(&obj.Spec); inherited != "" {
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[bookstoreexamplecomv1.InheritsAnnotation] = inherited
+		} else {
+			delete(annotations, bookstoreexamplecomv1.InheritsAnnotation)
+		}
+	} else {
+		labels[bookstoreexamplecomv1.BookTypeLabel] = bookstoreexamplecomv1.BookTypeOriginal
+		// A name generated by the API server is not known yet, so the label is left for the next update.
+		if obj.GetName() != "" {
+			labels[bookstoreexamplecomv1.OriginalRefLabel] = bookstoreexamplecomv1.OriginalRefHash(obj.GetNamespace(), obj.GetName())
+		} else {
+			delete(labels, bookstoreexamplecomv1.OriginalRefLabel)
+		}
+		delete(annotations, bookstoreexamplecomv1.InheritsAnnotation)
+	}
+	obj.SetLabels(labels)
+	obj.SetAnnotations(annotations)
+	return recordUsers(ctx, obj)
+}
+
+// recordUsers sets CreatedByAnnotation and LastModifiedByAnnotation from the admission request. On
+// update both start from the stored Book, so whatever the user wrote is discarded, and the last
+// modifier only moves when the spec changes, so the operator's own metadata updates do not count.
+// Outside an admission request there is no user to record.
+func recordUsers(ctx context.Context, obj *bookstoreexamplecomv1.Book) error {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil
+	}
+	createdBy, lastModifiedBy := req.UserInfo.Username, req.UserInfo.Username
+	if req.Operation == admissionv1.Update {
+		var stored bookstoreexamplecomv1.Book
+		if err := json.Unmarshal(req.OldObject.Raw, &stored); err != nil {
+			return fmt.Errorf("failed to decode the stored Book: %w", err)
+		}
+		createdBy = stored.Annotations[bookstoreexamplecomv1.CreatedByAnnotation]
+		if equality.Semantic.DeepEqual(stored.Spec, obj.Spec) {
+			lastModifiedBy = stored.Annotations[bookstoreexamplecomv1.LastModifiedByAnnotation]
+		}
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for key, value := range map[string]string{
+		bookstoreexamplecomv1.CreatedByAnnotation:      createdBy,
+		bookstoreexamplecomv1.LastModifiedByAnnotation: lastModifiedBy,
+	} {
+		if value != "" {
+			annotations[key] = value
+		} else {
+			delete(annotations, key)
+		}
+	}
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// normalizeTitle trims the title and collapses runs of whitespace inside it.
+func normalizeTitle(title string) string {
+	return strings.Join(strings.Fields(title), " ")
+}
+
+// normalizeGenre collapses whitespace like normalizeTitle and capitalizes each word, so "science
+// fiction" and "Science  Fiction" end up the same genre.
+func normalizeGenre(genre string) string {
+	words := strings.Fields(genre)
+	for i, word := range words {
+		runes := []rune(strings.ToLower(word))
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
+
+// normalizePrice writes a plain decimal price, optionally prefixed with "$", with two decimal
+// places. Other values are only trimmed.
+func normalizePrice(price string) string {
+	price = strings.TrimSpace(price)
+	amount, ok := parseAmount(price)
+	if !ok {
+		return price
+	}
+	return amount.FloatString(2)
+}
+
+// parseAmount parses a price matching pricePattern.
+func parseAmount(price string) (*big.Rat, bool) {
+	if !pricePattern.MatchString(price) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(strings.TrimSpace(strings.TrimPrefix(price, "$")))
+}
+
+// parsePrice returns a price matching pricePattern as a float, for comparing prices.
+func parsePrice(price string) (float64, bool) {
+	amount, ok := parseAmount(strings.TrimSpace(price))
+	if !ok {
+		return 0, false
+	}
+	f, _ := amount.Float64()
+	return f, true
+}
+
+// inheritedFields lists the spec fields a copy leaves empty, in the format of InheritsAnnotation.
+func inheritedFields(spec *bookstoreexamplecomv1.BookSpec) string {
+	var fields []string
+	if spec.Title == "" {
+		fields = append(fields, "title")
+	}
+	if spec.Price == "" {
+		fields = append(fields, "price")
+	}
+	if spec.Genre == "" {
+		fields = append(fields, "genre")
+	}
+	return strings.Join(fields, ",")
+}
+
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 // NOTE: If you want to customise the 'path', use the flags '--defaulting-path' or '--validation-path'.
 // +kubebuilder:webhook:path=/validate-bookstore-example-com-v1-book,mutating=false,failurePolicy=fail,sideEffects=None,groups=bookstore.example.com,resources=books,verbs=create;update,versions=v1,name=vbook-v1.kb.io,admissionReviewVersions=v1
@@ -54,53 +263,175 @@ func SetupBookWebhookWithManager(mgr ctrl.Manager) error {
 // as this struct is used only for temporary operations and does not need to be deeply copied.
 type BookCustomValidator struct {
 	Client client.Client
+
+	// APIReader looks up copyOf targets on the API server rather than in the cache, which may not be
+	// synced yet or may not cover the target's namespace. Defaults to Client.
+	APIReader client.Reader
+
+	// AllowUnmanagedNamespaces skips the check that a new Book lives in a BookStore namespace.
+	AllowUnmanagedNamespaces bool
+
+	// Config is consulted on every request, so toggles in the configuration file apply without a restart.
+	Config *config.Watcher
 }
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Book.
 func (v *BookCustomValidator) ValidateCreate(ctx context.Context, obj *bookstoreexamplecomv1.Book) (admission.Warnings, error) {
 	booklog.Info("Validation for Book upon creation", "name", obj.GetName())
 
-	if err := v.validateCopyOfReference(ctx, obj); err != nil {
-		return nil, err
-	}
+	allErrs := v.validateManagedNamespace(ctx, obj)
+	warnings, specErrs := v.validateSpec(ctx, nil, obj)
+	allErrs = append(allErrs, specErrs...)
+	return warnings, invalidBook(obj, allErrs)
+}
 
-	if !hasAtLeastOneOverride(&obj.Spec) {
-		return nil, fmt.Errorf("a Book with copyOf must override title, price, or genre in spec")
-	}
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Book.
+func (v *BookCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj *bookstoreexamplecomv1.Book) (admission.Warnings, error) {
+	booklog.Info("Validation for Book upon update", "name", newObj.GetName())
 
-	if !hasRequiredFieldsWhenNotCopy(&obj.Spec) {
-		return nil, fmt.Errorf("a Book without copyOf must have spec.title, spec.price, and spec.genre set (non-zero)")
-	}
+	allErrs := v.validateTransition(oldObj, newObj)
+	warnings, specErrs := v.validateSpec(ctx, oldObj, newObj)
+	allErrs = append(allErrs, specErrs...)
+	return warnings, invalidBook(newObj, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Book.
+func (v *BookCustomValidator) ValidateDelete(_ context.Context, obj *bookstoreexamplecomv1.Book) (admission.Warnings, error) {
+	booklog.Info("Validation for Book upon deletion", "name", obj.GetName())
+
+	// TODO(user): fill in your validation logic upon object deletion.
 
 	return nil, nil
 }
 
-// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Book.
-func (v *BookCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj *bookstoreexamplecomv1.Book) (admission.Warnings, error) {
-	booklog.Info("Validation for Book upon update", "name", newObj.GetName())
+// validateTransition checks how spec.copyOf may change on update. An original that is copied cannot
+// become a copy, which would leave its copies pointing at a copy, and a Book other Books still count
+// as their original cannot stop being one. With immutableCopyOf set, spec.copyOf cannot change at all.
+func (v *BookCustomValidator) validateTransition(oldObj, newObj *bookstoreexamplecomv1.Book) field.ErrorList {
+	copyOfPath := field.NewPath("spec", "copyOf")
+	if v.Config.Current().Webhooks.Book.ImmutableCopyOf {
+		if errs := apivalidation.ValidateImmutableField(newObj.Spec.CopyOf, oldObj.Spec.CopyOf, copyOfPath); len(errs) > 0 {
+			return errs
+		}
+	}
 
-	if err := v.validateCopyOfReference(ctx, newObj); err != nil {
-		return nil, err
+	wasCopy, isCopy := oldObj.Spec.CopyOf != nil, newObj.Spec.CopyOf != nil
+	referenceCount := oldObj.Status.ReferenceCount
+	if wasCopy == isCopy || referenceCount == 0 {
+		return nil
+	}
+	if isCopy {
+		return field.ErrorList{field.Forbidden(copyOfPath, fmt.Sprintf(
+			"an original with %d copies cannot become a copy, because its copies would then point at a copy; "+
+				"delete or repoint the copies first", referenceCount))}
 	}
+	return field.ErrorList{field.Forbidden(copyOfPath, fmt.Sprintf(
+		"cannot be removed while %d Books still count this Book as their original", referenceCount))}
+}
 
-	if !hasAtLeastOneOverride(&newObj.Spec) {
-		return nil, fmt.Errorf("when spec.copyOf is set, at least one of spec.title, spec.price, or spec.genre must be set (non-zero)")
+// reader returns APIReader, or Client when it is not set. It is nil when neither is.
+func (v *BookCustomValidator) reader() client.Reader {
+	if v.APIReader != nil {
+		return v.APIReader
+	}
+	if v.Client != nil {
+		return v.Client
 	}
+	return nil
+}
 
-	if !hasRequiredFieldsWhenNotCopy(&newObj.Spec) {
-		return nil, fmt.Errorf("a Book without copyOf must have spec.title, spec.price, and spec.genre set (non-zero)")
+// invalidBook wraps allErrs in the Invalid status error the API server returns to the client, or
+// returns nil when there is nothing to report.
+func invalidBook(obj *bookstoreexamplecomv1.Book, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
 	}
+	return apierrors.NewInvalid(bookstoreexamplecomv1.GroupVersion.WithKind("Book").GroupKind(), obj.GetName(), allErrs)
+}
 
-	return nil, nil
+// validateSpec checks the rules shared by create and update and reports every violation, together
+// with warnings about Books that are legal but probably not what the user meant. oldObj is nil on
+// create.
+func (v *BookCustomValidator) validateSpec(ctx context.Context, oldObj, obj *bookstoreexamplecomv1.Book) (admission.Warnings, field.ErrorList) {
+	specPath := field.NewPath("spec")
+	warnings := v.warnings(ctx, obj)
+	var allErrs field.ErrorList
+	if obj.Spec.CopyOf != nil {
+		original, copyOfErrs := v.validateCopyOfReference(ctx, oldObj, obj)
+		allErrs = append(allErrs, copyOfErrs...)
+		if !hasAtLeastOneOverride(&obj.Spec) {
+			allErrs = append(allErrs, field.Required(specPath,
+				"a Book with copyOf must override at least one of title, price, or genre"))
+		}
+		if original != nil {
+			warnings = append(warnings, copyWarnings(obj, original)...)
+		}
+		return warnings, allErrs
+	}
+	if obj.Spec.Title == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("title"), "required for a Book without copyOf"))
+	}
+	if obj.Spec.Price == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("price"), "required for a Book without copyOf"))
+	}
+	if obj.Spec.Genre == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("genre"), "required for a Book without copyOf"))
+	}
+	return warnings, allErrs
 }
 
-// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Book.
-func (v *BookCustomValidator) ValidateDelete(_ context.Context, obj *bookstoreexamplecomv1.Book) (admission.Warnings, error) {
-	booklog.Info("Validation for Book upon deletion", "name", obj.GetName())
+// priceWarningRatio is how far a copy's price may drift from the original's, in either direction,
+// before the webhook warns about it.
+const priceWarningRatio = 2.0
 
-	// TODO(user): fill in your validation logic upon object deletion.
+// warnings flags a genre outside the known taxonomy and a title already used by another Book in the
+// same store. None of them block the request. The title check reads the store's Books from the API
+// server, like the copyOf lookup, and is skipped by a validator without a reader.
+func (v *BookCustomValidator) warnings(ctx context.Context, obj *bookstoreexamplecomv1.Book) admission.Warnings {
+	var warnings admission.Warnings
+	if genres := v.Config.Current().Webhooks.Book.KnownGenres; obj.Spec.Genre != "" && len(genres) > 0 &&
+		!slices.ContainsFunc(genres, func(genre string) bool { return strings.EqualFold(genre, obj.Spec.Genre) }) {
+		warnings = append(warnings, fmt.Sprintf("spec.genre: %q is not one of the known genres %s",
+			obj.Spec.Genre, strings.Join(genres, ", ")))
+	}
 
-	return nil, nil
+	reader := v.reader()
+	if obj.Spec.Title == "" || reader == nil {
+		return warnings
+	}
+	var books bookstoreexamplecomv1.BookList
+	if err := reader.List(ctx, &books, client.InNamespace(obj.GetNamespace())); err != nil {
+		booklog.Error(err, "Failed to list Books for the duplicate title check", "namespace", obj.GetNamespace())
+		return warnings
+	}
+	for _, book := range books.Items {
+		if book.Name != obj.GetName() && strings.EqualFold(book.Spec.Title, obj.Spec.Title) {
+			warnings = append(warnings, fmt.Sprintf("spec.title: Book %q in this store already has the title %q",
+				book.Name, book.Spec.Title))
+			break
+		}
+	}
+	return warnings
+}
+
+// copyWarnings flags a copy that overrides every field of its original, or whose price is far from
+// the original's.
+func copyWarnings(obj, original *bookstoreexamplecomv1.Book) admission.Warnings {
+	var warnings admission.Warnings
+	if obj.Spec.Title != "" && obj.Spec.Price != "" && obj.Spec.Genre != "" {
+		warnings = append(warnings, fmt.Sprintf(
+			"spec: this copy overrides title, price and genre, so nothing is taken from %s/%s anymore",
+			original.Namespace, original.Name))
+	}
+	copyPrice, copyOK := parsePrice(obj.Spec.Price)
+	originalPrice, originalOK := parsePrice(original.Spec.Price)
+	if copyOK && originalOK && originalPrice > 0 {
+		if ratio := copyPrice / originalPrice; ratio > priceWarningRatio || ratio < 1/priceWarningRatio {
+			warnings = append(warnings, fmt.Sprintf("spec.price: %s is far from the original's price %s",
+				obj.Spec.Price, original.Spec.Price))
+		}
+	}
+	return warnings
 }
 
 func hasAtLeastOneOverride(spec *bookstoreexamplecomv1.BookSpec) bool {
@@ -110,35 +441,171 @@ func hasAtLeastOneOverride(spec *bookstoreexamplecomv1.BookSpec) bool {
 	return spec.Title != "" || spec.Price != "" || spec.Genre != ""
 }
 
-func hasRequiredFieldsWhenNotCopy(spec *bookstoreexamplecomv1.BookSpec) bool {
-	if spec.CopyOf != nil {
-		return true
+// validateManagedNamespace rejects Books created outside a BookStore namespace, since no store
+// finalizer would ever clean them up.
+func (v *BookCustomValidator) validateManagedNamespace(ctx context.Context, obj *bookstoreexamplecomv1.Book) field.ErrorList {
+	if v.AllowUnmanagedNamespaces || v.Config.Current().Webhooks.Book.AllowUnmanagedNamespaces {
+		return nil
 	}
-	return spec.Title != "" && spec.Price != "" && spec.Genre != ""
+	namespacePath := field.NewPath("metadata", "namespace")
+	bookstore, err := stores.ForNamespace(ctx, v.Client, obj.GetNamespace())
+	if err != nil {
+		return field.ErrorList{field.InternalError(namespacePath,
+			fmt.Errorf("failed to look up the BookStore for namespace %q: %w", obj.GetNamespace(), err))}
+	}
+	if bookstore == nil {
+		return field.ErrorList{field.Invalid(namespacePath, obj.GetNamespace(), "not managed by any BookStore")}
+	}
+	if bookstore.DeletionTimestamp != nil {
+		return field.ErrorList{field.Forbidden(namespacePath,
+			fmt.Sprintf("BookStore %q is being deleted, no new Books can be added to it", bookstore.Name))}
+	}
+	return nil
 }
 
-func (v *BookCustomValidator) validateCopyOfReference(ctx context.Context, obj *bookstoreexamplecomv1.Book) error {
-	booklog.Info("Validating spec.copyOf reference", "namespace", obj.GetNamespace(), "name", obj.GetName())
-	if obj.Spec.CopyOf == nil {
-		return nil
-	}
+// validateCopyOfReference checks spec.copyOf and returns the original it points at when it could be read.
+func (v *BookCustomValidator) validateCopyOfReference(ctx context.Context, oldObj, obj *bookstoreexamplecomv1.Book) (*bookstoreexamplecomv1.Book, field.ErrorList) {
 	copyOf := obj.Spec.CopyOf
+	copyOfPath := field.NewPath("spec", "copyOf")
 	booklog.Info("Validating spec.copyOf reference", "namespace", obj.GetNamespace(), "name", obj.GetName(),
 		"copyOf.namespace", copyOf.Namespace, "copyOf.name", copyOf.Name)
 
+	var allErrs field.ErrorList
+	if copyOf.Namespace == "" {
+		allErrs = append(allErrs, field.Required(copyOfPath.Child("namespace"), ""))
+	}
+	if copyOf.Name == "" {
+		allErrs = append(allErrs, field.Required(copyOfPath.Child("name"), ""))
+	}
+	if len(allErrs) > 0 {
+		return nil, allErrs
+	}
+
 	if obj.GetNamespace() == copyOf.Namespace && obj.GetName() == copyOf.Name {
-		return fmt.Errorf("book cannot reference itself in spec.copyOf")
+		return nil, field.ErrorList{field.Invalid(copyOfPath, copyOf.Namespace+"/"+copyOf.Name,
+			"a Book cannot be a copy of itself")}
 	}
-	ref := bookstoreexamplecomv1.Book{}
-	err := v.Client.Get(ctx, types.NamespacedName{Namespace: copyOf.Namespace, Name: copyOf.Name}, &ref)
+	// The access check comes before the lookup, so a user who may not read the original cannot
+	// tell from the answer whether it exists.
+	if oldObj == nil || oldObj.Spec.CopyOf == nil || *oldObj.Spec.CopyOf != *copyOf {
+		if errs := v.authorizeCopy(ctx, copyOf); len(errs) > 0 {
+			return nil, errs
+		}
+		if errs := v.validateCopyPolicy(ctx, obj); len(errs) > 0 {
+			return nil, errs
+		}
+	}
+	ref, err := v.lookupOriginal(ctx, copyOf)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return fmt.Errorf("spec.copyOf references non-existent Book")
+			return nil, field.ErrorList{field.NotFound(copyOfPath.Child("name"), copyOf.Name)}
 		}
-		return fmt.Errorf("failed to validate spec.copyOf reference")
+		return nil, field.ErrorList{field.InternalError(copyOfPath, err)}
 	}
 	if ref.Spec.CopyOf != nil {
-		return fmt.Errorf("a copy cannot reference another copy, only originals can be copied")
+		return ref, field.ErrorList{field.Invalid(copyOfPath.Child("name"), copyOf.Name,
+			"references a copy, only originals can be copied")}
+	}
+	return ref, nil
+}
+
+// lookupOriginal reads the Book copyOf points at from the API server, giving up after the configured
+// lookup timeout. A missing Book is returned as the NotFound error, any other failure says so.
+func (v *BookCustomValidator) lookupOriginal(ctx context.Context, copyOf *bookstoreexamplecomv1.CopyOf) (*bookstoreexamplecomv1.Book, error) {
+	reader := v.reader()
+	timeout := v.Config.Current().Webhooks.Book.LookupTimeout.Duration
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ref := &bookstoreexamplecomv1.Book{}
+	start := time.Now()
+	err := reader.Get(ctx, types.NamespacedName{Namespace: copyOf.Namespace, Name: copyOf.Name}, ref)
+	result := "found"
+	switch {
+	case err == nil:
+	case apierrors.IsNotFound(err):
+		result = "not_found"
+	case errors.Is(err, context.DeadlineExceeded):
+		result = "error"
+		err = fmt.Errorf("timed out after %s looking up Book %s/%s", timeout, copyOf.Namespace, copyOf.Name)
+	default:
+		result = "error"
+		err = fmt.Errorf("failed to look up Book %s/%s: %w", copyOf.Namespace, copyOf.Name, err)
+	}
+	metrics.CopyOfLookupDuration.WithLabelValues(result).Observe(time.Since(start).Seconds())
+	if err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
+
+// validateCopyPolicy enforces the copy policy of the store holding the original.
+func (v *BookCustomValidator) validateCopyPolicy(ctx context.Context, obj *bookstoreexamplecomv1.Book) field.ErrorList {
+	copyOfPath := field.NewPath("spec", "copyOf")
+	source, err := stores.ForNamespace(ctx, v.Client, obj.Spec.CopyOf.Namespace)
+	if err != nil {
+		return field.ErrorList{field.InternalError(copyOfPath,
+			fmt.Errorf("failed to look up the BookStore for namespace %q: %w", obj.Spec.CopyOf.Namespace, err))}
+	}
+	if source == nil {
+		return nil
+	}
+	target, err := stores.ForNamespace(ctx, v.Client, obj.GetNamespace())
+	if err != nil {
+		return field.ErrorList{field.InternalError(copyOfPath,
+			fmt.Errorf("failed to look up the BookStore for namespace %q: %w", obj.GetNamespace(), err))}
+	}
+	allowed, err := stores.AllowsCopy(source, target)
+	if err != nil {
+		return field.ErrorList{field.InternalError(copyOfPath, err)}
+	}
+	if !allowed {
+		return field.ErrorList{field.Forbidden(copyOfPath,
+			fmt.Sprintf("the copy policy of BookStore %q does not allow copies in namespace %q",
+				source.Name, obj.GetNamespace()))}
+	}
+	return nil
+}
+
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// authorizeCopy asks the API server whether the user making the admission request may get the
+// original, since the webhook reads it with the operator's own permissions. Requests that did not
+// come through the admission handler carry no user and are not checked.
+func (v *BookCustomValidator) authorizeCopy(ctx context.Context, copyOf *bookstoreexamplecomv1.CopyOf) field.ErrorList {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil
+	}
+	namespacePath := field.NewPath("spec", "copyOf", "namespace")
+	extra := make(map[string]authorizationv1.ExtraValue, len(req.UserInfo.Extra))
+	for key, value := range req.UserInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   req.UserInfo.Username,
+			Groups: req.UserInfo.Groups,
+			UID:    req.UserInfo.UID,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: copyOf.Namespace,
+				Verb:      "get",
+				Group:     bookstoreexamplecomv1.GroupVersion.Group,
+				Resource:  "books",
+				Name:      copyOf.Name,
+			},
+		},
+	}
+	if err := v.Client.Create(ctx, review); err != nil {
+		return field.ErrorList{field.InternalError(namespacePath,
+			fmt.Errorf("failed to check whether %q may get Books in namespace %q: %w",
+				req.UserInfo.Username, copyOf.Namespace, err))}
+	}
+	if !review.Status.Allowed {
+		return field.ErrorList{field.Forbidden(namespacePath,
+			fmt.Sprintf("user %q cannot get Books in namespace %q, so it cannot copy them", req.UserInfo.Username,
+				copyOf.Namespace))}
 	}
 	return nil
 }
@@ -18,13 +18,31 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+	"github.com/danieldanieltata/bookstore-operator/internal/config"
+	"github.com/danieldanieltata/bookstore-operator/internal/metrics"
+	"github.com/danieldanieltata/bookstore-operator/internal/stores"
 )
 
 func testScheme() *runtime.Scheme {
@@ -33,24 +51,74 @@ func testScheme() *runtime.Scheme {
 	return s
 }
 
+// newFakeClientBuilder returns a fake client builder with the field indexes the stores lookups select on.
+func newFakeClientBuilder(s *runtime.Scheme) *fake.ClientBuilder {
+	return fake.NewClientBuilder().WithScheme(s).
+		WithIndex(&bookstoreexamplecomv1.BookStore{}, stores.NameField, stores.IndexName).
+		WithIndex(&bookstoreexamplecomv1.Book{}, stores.CopyOfNamespaceField, stores.IndexCopyOfNamespace)
+}
+
+// expectFieldErrors checks that err is an Invalid status error whose causes are exactly want, each
+// written as "<field> <cause type>".
+func expectFieldErrors(t *testing.T, err error, want ...string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("expected field errors %v, got none", want)
+	}
+	if !apierrors.IsInvalid(err) {
+		t.Fatalf("expected an Invalid error, got %v", err)
+	}
+	var got []string
+	for _, cause := range err.(apierrors.APIStatus).Status().Details.Causes {
+		got = append(got, cause.Field+" "+string(cause.Type))
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected field errors %v, got %v (%v)", want, got, err)
+	}
+}
+
+// watcherWithConfig returns a config watcher reading an OperatorConfig whose webhooks section is
+// yaml.
+func watcherWithConfig(t *testing.T, yaml string) *config.Watcher {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(
+		"apiVersion: bookstore.example.com/v1alpha1\nkind: OperatorConfig\nwebhooks:\n"+yaml,
+	), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	operatorConfig, err := config.NewWatcher(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return operatorConfig
+}
+
+// newCopy returns a Book named "copy" in namespace that copies original and overrides only the price.
+func newCopy(namespace string, original bookstoreexamplecomv1.CopyOf) *bookstoreexamplecomv1.Book {
+	return &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "copy"},
+		Spec:       bookstoreexamplecomv1.BookSpec{Price: "2", CopyOf: &original},
+	}
+}
+
 func TestValidateCreate_RejectsMissingRequiredFields(t *testing.T) {
-	v := BookCustomValidator{}
+	v := BookCustomValidator{AllowUnmanagedNamespaces: true}
 	obj := &bookstoreexamplecomv1.Book{}
 	obj.Spec.Title = ""
 	obj.Spec.Price = ""
 	obj.Spec.Genre = ""
 
 	_, err := v.ValidateCreate(context.Background(), obj)
-	if err == nil {
-		t.Fatal("expected error when required fields missing")
-	}
-	if msg := err.Error(); msg != "a Book without copyOf must have spec.title, spec.price, and spec.genre set (non-zero)" {
-		t.Errorf("unexpected error: %s", msg)
-	}
+	expectFieldErrors(t, err,
+		"spec.title FieldValueRequired",
+		"spec.price FieldValueRequired",
+		"spec.genre FieldValueRequired",
+	)
 }
 
 func TestValidateCreate_AllowsValidBook(t *testing.T) {
-	v := BookCustomValidator{}
+	v := BookCustomValidator{AllowUnmanagedNamespaces: true}
 	obj := &bookstoreexamplecomv1.Book{}
 	obj.Spec.Title = "The Book"
 	obj.Spec.Price = "10"
@@ -63,19 +131,17 @@ func TestValidateCreate_AllowsValidBook(t *testing.T) {
 }
 
 func TestValidateCreate_RejectsSelfReference(t *testing.T) {
-	v := BookCustomValidator{}
+	v := BookCustomValidator{AllowUnmanagedNamespaces: true}
 	obj := &bookstoreexamplecomv1.Book{}
 	obj.SetNamespace("default")
 	obj.SetName("mybook")
 	obj.Spec.CopyOf = &bookstoreexamplecomv1.CopyOf{Namespace: "default", Name: "mybook"}
 
 	_, err := v.ValidateCreate(context.Background(), obj)
-	if err == nil {
-		t.Fatal("expected error for self-reference")
-	}
-	if msg := err.Error(); msg != "book cannot reference itself in spec.copyOf" {
-		t.Errorf("unexpected error: %s", msg)
-	}
+	expectFieldErrors(t, err,
+		"spec.copyOf FieldValueInvalid",
+		"spec FieldValueRequired",
+	)
 }
 
 func TestValidateCreate_CopyOfScenarios(t *testing.T) {
@@ -92,25 +158,23 @@ func TestValidateCreate_CopyOfScenarios(t *testing.T) {
 	}
 
 	t.Run("rejects nonexistent reference", func(t *testing.T) {
-		c := fake.NewClientBuilder().WithScheme(testScheme()).Build()
-		v := BookCustomValidator{Client: c}
+		c := newFakeClientBuilder(testScheme()).Build()
+		v := BookCustomValidator{Client: c, AllowUnmanagedNamespaces: true}
 		obj := &bookstoreexamplecomv1.Book{}
 		obj.SetNamespace("default")
 		obj.SetName("new")
 		obj.Spec.CopyOf = &bookstoreexamplecomv1.CopyOf{Namespace: "default", Name: "nonexistent"}
 
 		_, err := v.ValidateCreate(context.Background(), obj)
-		if err == nil {
-			t.Fatal("expected error for nonexistent copyOf")
-		}
-		if msg := err.Error(); msg != "spec.copyOf references non-existent Book" {
-			t.Errorf("unexpected error: %s", msg)
-		}
+		expectFieldErrors(t, err,
+			"spec.copyOf.name FieldValueNotFound",
+			"spec FieldValueRequired",
+		)
 	})
 
 	t.Run("rejects copy-of-copy", func(t *testing.T) {
-		c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(original, copyBook).Build()
-		v := BookCustomValidator{Client: c}
+		c := newFakeClientBuilder(testScheme()).WithObjects(original, copyBook).Build()
+		v := BookCustomValidator{Client: c, AllowUnmanagedNamespaces: true}
 		obj := &bookstoreexamplecomv1.Book{}
 		obj.SetNamespace("default")
 		obj.SetName("new")
@@ -118,34 +182,24 @@ func TestValidateCreate_CopyOfScenarios(t *testing.T) {
 		obj.Spec.Title = "X"
 
 		_, err := v.ValidateCreate(context.Background(), obj)
-		if err == nil {
-			t.Fatal("expected error when copyOf points to a copy")
-		}
-		if msg := err.Error(); msg != "a copy cannot reference another copy, only originals can be copied" {
-			t.Errorf("unexpected error: %s", msg)
-		}
+		expectFieldErrors(t, err, "spec.copyOf.name FieldValueInvalid")
 	})
 
 	t.Run("rejects copyOf without override", func(t *testing.T) {
-		c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(original).Build()
-		v := BookCustomValidator{Client: c}
+		c := newFakeClientBuilder(testScheme()).WithObjects(original).Build()
+		v := BookCustomValidator{Client: c, AllowUnmanagedNamespaces: true}
 		obj := &bookstoreexamplecomv1.Book{}
 		obj.SetNamespace("default")
 		obj.SetName("new")
 		obj.Spec.CopyOf = &bookstoreexamplecomv1.CopyOf{Namespace: "default", Name: "original"}
 
 		_, err := v.ValidateCreate(context.Background(), obj)
-		if err == nil {
-			t.Fatal("expected error when copyOf has no override")
-		}
-		if msg := err.Error(); msg != "a Book with copyOf must override title, price, or genre in spec" {
-			t.Errorf("unexpected error: %s", msg)
-		}
+		expectFieldErrors(t, err, "spec FieldValueRequired")
 	})
 
 	t.Run("allows copyOf with override", func(t *testing.T) {
-		c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(original).Build()
-		v := BookCustomValidator{Client: c}
+		c := newFakeClientBuilder(testScheme()).WithObjects(original).Build()
+		v := BookCustomValidator{Client: c, AllowUnmanagedNamespaces: true}
 		obj := &bookstoreexamplecomv1.Book{}
 		obj.SetNamespace("default")
 		obj.SetName("new")
@@ -160,7 +214,7 @@ func TestValidateCreate_CopyOfScenarios(t *testing.T) {
 }
 
 func TestValidateUpdate_RejectsMissingRequiredFields(t *testing.T) {
-	v := BookCustomValidator{}
+	v := BookCustomValidator{AllowUnmanagedNamespaces: true}
 	oldObj := &bookstoreexamplecomv1.Book{}
 	oldObj.Spec.Title = "Old"
 	oldObj.Spec.Price = "5"
@@ -171,10 +225,529 @@ func TestValidateUpdate_RejectsMissingRequiredFields(t *testing.T) {
 	newObj.Spec.Genre = ""
 
 	_, err := v.ValidateUpdate(context.Background(), oldObj, newObj)
-	if err == nil {
-		t.Fatal("expected error when required fields missing")
+	expectFieldErrors(t, err,
+		"spec.title FieldValueRequired",
+		"spec.price FieldValueRequired",
+		"spec.genre FieldValueRequired",
+	)
+}
+
+func TestValidateCreate_ManagedNamespace(t *testing.T) {
+	newBook := func() *bookstoreexamplecomv1.Book {
+		obj := &bookstoreexamplecomv1.Book{}
+		obj.SetNamespace("tel-aviv")
+		obj.SetName("new")
+		obj.Spec.Title = "The Book"
+		obj.Spec.Price = "10"
+		obj.Spec.Genre = "Fiction"
+		return obj
+	}
+
+	t.Run("rejects namespace without a BookStore", func(t *testing.T) {
+		c := newFakeClientBuilder(testScheme()).Build()
+		v := BookCustomValidator{Client: c}
+
+		_, err := v.ValidateCreate(context.Background(), newBook())
+		expectFieldErrors(t, err, "metadata.namespace FieldValueInvalid")
+	})
+
+	t.Run("rejects namespace of a terminating BookStore", func(t *testing.T) {
+		now := metav1.Now()
+		store := &bookstoreexamplecomv1.BookStore{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default", Name: "tel-aviv",
+			DeletionTimestamp: &now, Finalizers: []string{"bookstore.example.com/finalizer"},
+		}}
+		c := newFakeClientBuilder(testScheme()).WithObjects(store).Build()
+		v := BookCustomValidator{Client: c}
+
+		_, err := v.ValidateCreate(context.Background(), newBook())
+		expectFieldErrors(t, err, "metadata.namespace FieldValueForbidden")
+		if !strings.Contains(err.Error(), `BookStore "tel-aviv" is being deleted`) {
+			t.Errorf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("allows namespace of an existing BookStore", func(t *testing.T) {
+		store := &bookstoreexamplecomv1.BookStore{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tel-aviv"}}
+		c := newFakeClientBuilder(testScheme()).WithObjects(store).Build()
+		v := BookCustomValidator{Client: c}
+
+		if _, err := v.ValidateCreate(context.Background(), newBook()); err != nil {
+			t.Fatalf("expected no error: %v", err)
+		}
+	})
+
+	t.Run("allows any namespace when opted out", func(t *testing.T) {
+		c := newFakeClientBuilder(testScheme()).Build()
+		v := BookCustomValidator{Client: c, AllowUnmanagedNamespaces: true}
+
+		if _, err := v.ValidateCreate(context.Background(), newBook()); err != nil {
+			t.Fatalf("expected no error: %v", err)
+		}
+	})
+}
+
+func TestDefault_NormalizesSpec(t *testing.T) {
+	c := newFakeClientBuilder(testScheme()).Build()
+	d := BookCustomDefaulter{Client: c}
+	obj := &bookstoreexamplecomv1.Book{}
+	obj.SetNamespace("tel-aviv")
+	obj.SetName("new")
+	obj.Spec.Title = "  The   Book "
+	obj.Spec.Price = " $10.5"
+	obj.Spec.Genre = "science  FICTION"
+
+	if err := d.Default(context.Background(), obj); err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+	if obj.Spec.Title != "The Book" || obj.Spec.Price != "10.50" || obj.Spec.Genre != "Science Fiction" {
+		t.Errorf("unexpected spec: %+v", obj.Spec)
+	}
+
+	obj.Spec.Price = "ten shekels"
+	if err := d.Default(context.Background(), obj); err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+	if obj.Spec.Price != "ten shekels" {
+		t.Errorf("expected an unrecognized price to be kept, got %q", obj.Spec.Price)
+	}
+}
+
+func TestDefault_SetsLabelsAndInheritMarkers(t *testing.T) {
+	store := &bookstoreexamplecomv1.BookStore{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tel-aviv"}}
+	c := newFakeClientBuilder(testScheme()).WithObjects(store).Build()
+	d := BookCustomDefaulter{Client: c}
+
+	original := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tel-aviv", Name: "original"},
+		Spec:       bookstoreexamplecomv1.BookSpec{Title: "Orig", Price: "1", Genre: "X"},
+	}
+	if err := d.Default(context.Background(), original); err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+	ref := bookstoreexamplecomv1.OriginalRefHash("tel-aviv", "original")
+	want := map[string]string{
+		bookstoreexamplecomv1.BookStoreLabel:   "tel-aviv",
+		bookstoreexamplecomv1.BookTypeLabel:    bookstoreexamplecomv1.BookTypeOriginal,
+		bookstoreexamplecomv1.OriginalRefLabel: ref,
+	}
+	for key, value := range want {
+		if got := original.Labels[key]; got != value {
+			t.Errorf("expected label %s=%s on the original, got %q", key, value, got)
+		}
+	}
+
+	copyBook := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "hadera", Name: "copy"},
+		Spec: bookstoreexamplecomv1.BookSpec{
+			Price:  "2",
+			CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: "tel-aviv", Name: "original"},
+		},
+	}
+	if err := d.Default(context.Background(), copyBook); err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+	if got := copyBook.Labels[bookstoreexamplecomv1.OriginalRefLabel]; got != ref {
+		t.Errorf("expected the copy to share the original's ref %s, got %q", ref, got)
+	}
+	if got := copyBook.Labels[bookstoreexamplecomv1.BookTypeLabel]; got != bookstoreexamplecomv1.BookTypeCopy {
+		t.Errorf("unexpected type label on the copy: %q", got)
+	}
+	if _, ok := copyBook.Labels[bookstoreexamplecomv1.BookStoreLabel]; ok {
+		t.Error("expected no store label in a namespace without a BookStore")
+	}
+	if got := copyBook.Annotations[bookstoreexamplecomv1.InheritsAnnotation]; got != "title,genre" {
+		t.Errorf("unexpected inherit marker: %q", got)
+	}
+
+	const step = "turning the copy into an original drops the marker"
+	copyBook.Spec = bookstoreexamplecomv1.BookSpec{Title: "Own", Price: "2", Genre: "Y"}
+	if err := d.Default(context.Background(), copyBook); err != nil {
+		t.Fatalf("%s: expected no error: %v", step, err)
+	}
+	if _, ok := copyBook.Annotations[bookstoreexamplecomv1.InheritsAnnotation]; ok {
+		t.Errorf("%s: annotation still set", step)
+	}
+}
+
+func TestDefault_RecordsCreatorAndLastModifier(t *testing.T) {
+	c := newFakeClientBuilder(testScheme()).Build()
+	d := BookCustomDefaulter{Client: c}
+	request := func(operation admissionv1.Operation, user string, stored *bookstoreexamplecomv1.Book) context.Context {
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: operation,
+			UserInfo:  authenticationv1.UserInfo{Username: user},
+		}}
+		if stored != nil {
+			raw, err := json.Marshal(stored)
+			if err != nil {
+				t.Fatalf("failed to encode the stored Book: %v", err)
+			}
+			req.OldObject = runtime.RawExtension{Raw: raw}
+		}
+		return admission.NewContextWithRequest(context.Background(), req)
+	}
+	expectUsers := func(step string, book *bookstoreexamplecomv1.Book, createdBy, lastModifiedBy string) {
+		t.Helper()
+		if got := book.Annotations[bookstoreexamplecomv1.CreatedByAnnotation]; got != createdBy {
+			t.Errorf("%s: expected created-by %q, got %q", step, createdBy, got)
+		}
+		if got := book.Annotations[bookstoreexamplecomv1.LastModifiedByAnnotation]; got != lastModifiedBy {
+			t.Errorf("%s: expected last-modified-by %q, got %q", step, lastModifiedBy, got)
+		}
+	}
+
+	book := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "tel-aviv",
+			Name:        "original",
+			Annotations: map[string]string{bookstoreexamplecomv1.CreatedByAnnotation: "someone-else"},
+		},
+		Spec: bookstoreexamplecomv1.BookSpec{Title: "Orig", Price: "1", Genre: "X"},
+	}
+	if err := d.Default(request(admissionv1.Create, "alice", nil), book); err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+	expectUsers("create", book, "alice", "alice")
+
+	stored := book.DeepCopy()
+	book.Annotations[bookstoreexamplecomv1.CreatedByAnnotation] = "mallory"
+	book.Annotations[bookstoreexamplecomv1.LastModifiedByAnnotation] = "mallory"
+	book.Labels["team"] = "fantasy"
+	if err := d.Default(request(admissionv1.Update, "operator", stored), book); err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+	expectUsers("metadata update", book, "alice", "alice")
+
+	stored = book.DeepCopy()
+	book.Spec.Price = "12"
+	if err := d.Default(request(admissionv1.Update, "bob", stored), book); err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+	expectUsers("price change", book, "alice", "bob")
+}
+
+func TestValidateCreate_ReportsEveryViolation(t *testing.T) {
+	c := newFakeClientBuilder(testScheme()).Build()
+	v := BookCustomValidator{Client: c}
+	obj := &bookstoreexamplecomv1.Book{}
+	obj.SetNamespace("tel-aviv")
+	obj.SetName("new")
+	obj.Spec.CopyOf = &bookstoreexamplecomv1.CopyOf{Namespace: "default"}
+
+	_, err := v.ValidateCreate(context.Background(), obj)
+	expectFieldErrors(t, err,
+		"metadata.namespace FieldValueInvalid",
+		"spec.copyOf.name FieldValueRequired",
+		"spec FieldValueRequired",
+	)
+}
+
+func TestValidateCreate_KeepsTheLookupError(t *testing.T) {
+	lookupErr := errors.New("connection refused")
+	c := newFakeClientBuilder(testScheme()).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+			return lookupErr
+		},
+	}).Build()
+	v := BookCustomValidator{Client: c, AllowUnmanagedNamespaces: true}
+	obj := &bookstoreexamplecomv1.Book{}
+	obj.SetNamespace("default")
+	obj.SetName("new")
+	obj.Spec.CopyOf = &bookstoreexamplecomv1.CopyOf{Namespace: "default", Name: "original"}
+	obj.Spec.Title = "X"
+
+	_, err := v.ValidateCreate(context.Background(), obj)
+	expectFieldErrors(t, err, "spec.copyOf InternalError")
+	if !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("expected the lookup error in %q", err)
+	}
+}
+
+func lookupCount(t *testing.T, result string) uint64 {
+	t.Helper()
+	var m dto.Metric
+	if err := metrics.CopyOfLookupDuration.WithLabelValues(result).(prometheus.Histogram).Write(&m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestValidateCreate_LooksUpCopyOfOnTheAPIServer(t *testing.T) {
+	original := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "original"},
+		Spec:       bookstoreexamplecomv1.BookSpec{Title: "Orig", Price: "1", Genre: "X"},
+	}
+	copyOfOriginal := bookstoreexamplecomv1.CopyOf{Namespace: "default", Name: "original"}
+
+	t.Run("finds an original the cache has not seen", func(t *testing.T) {
+		found := lookupCount(t, "found")
+		v := BookCustomValidator{
+			Client:                   newFakeClientBuilder(testScheme()).Build(),
+			APIReader:                newFakeClientBuilder(testScheme()).WithObjects(original).Build(),
+			AllowUnmanagedNamespaces: true,
+		}
+
+		if _, err := v.ValidateCreate(context.Background(), newCopy("default", copyOfOriginal)); err != nil {
+			t.Fatalf("expected no error: %v", err)
+		}
+		if got := lookupCount(t, "found") - found; got != 1 {
+			t.Errorf("expected 1 recorded lookup, got %d", got)
+		}
+	})
+
+	t.Run("gives up after the lookup timeout", func(t *testing.T) {
+		failed := lookupCount(t, "error")
+		v := BookCustomValidator{
+			Client: newFakeClientBuilder(testScheme()).Build(),
+			APIReader: newFakeClientBuilder(testScheme()).WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, _ client.WithWatch, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+					<-ctx.Done()
+					return ctx.Err()
+				},
+			}).Build(),
+			AllowUnmanagedNamespaces: true,
+			Config:                   watcherWithConfig(t, "  book:\n    lookupTimeout: 10ms\n"),
+		}
+
+		_, err := v.ValidateCreate(context.Background(), newCopy("default", copyOfOriginal))
+		expectFieldErrors(t, err, "spec.copyOf InternalError")
+		if !strings.Contains(err.Error(), "timed out after 10ms looking up Book default/original") {
+			t.Errorf("unexpected error: %s", err)
+		}
+		if got := lookupCount(t, "error") - failed; got != 1 {
+			t.Errorf("expected 1 recorded failed lookup, got %d", got)
+		}
+	})
+}
+
+func TestValidate_WarnsAboutSuspiciousBooks(t *testing.T) {
+	original := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tel-aviv", Name: "original"},
+		Spec:       bookstoreexamplecomv1.BookSpec{Title: "Dune", Price: "10.00", Genre: "Science Fiction"},
+	}
+	c := newFakeClientBuilder(testScheme()).WithObjects(original).Build()
+	v := BookCustomValidator{Client: c, AllowUnmanagedNamespaces: true}
+
+	expectWarnings := func(t *testing.T, warnings admission.Warnings, err error, want ...string) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("expected warnings only, got %v", err)
+		}
+		if len(warnings) != len(want) {
+			t.Fatalf("expected %d warnings, got %v", len(want), warnings)
+		}
+		for i := range want {
+			if !strings.HasPrefix(warnings[i], want[i]) {
+				t.Errorf("expected warning %q, got %q", want[i], warnings[i])
+			}
+		}
+	}
+
+	t.Run("duplicate title and unknown genre", func(t *testing.T) {
+		obj := &bookstoreexamplecomv1.Book{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tel-aviv", Name: "another"},
+			Spec:       bookstoreexamplecomv1.BookSpec{Title: "dune", Price: "12.00", Genre: "Space Opera"},
+		}
+		warnings, err := v.ValidateCreate(context.Background(), obj)
+		expectWarnings(t, warnings, err, `spec.genre: "Space Opera" is not one of the known genres`,
+			`spec.title: Book "original" in this store already has the title "Dune"`)
+	})
+
+	t.Run("a Book does not duplicate itself", func(t *testing.T) {
+		warnings, err := v.ValidateUpdate(context.Background(), original, original.DeepCopy())
+		expectWarnings(t, warnings, err)
+	})
+
+	t.Run("copy overriding everything at a far price", func(t *testing.T) {
+		obj := newCopy("hadera", bookstoreexamplecomv1.CopyOf{Namespace: "tel-aviv", Name: "original"})
+		obj.Spec.Title, obj.Spec.Price, obj.Spec.Genre = "Dune (signed)", "45.00", "Science Fiction"
+		warnings, err := v.ValidateCreate(context.Background(), obj)
+		expectWarnings(t, warnings, err, "spec: this copy overrides title, price and genre",
+			"spec.price: 45.00 is far from the original's price 10.00")
+	})
+
+	t.Run("copy at a close price", func(t *testing.T) {
+		obj := newCopy("hadera", bookstoreexamplecomv1.CopyOf{Namespace: "tel-aviv", Name: "original"})
+		obj.Spec.Price = "12.00"
+		warnings, err := v.ValidateCreate(context.Background(), obj)
+		expectWarnings(t, warnings, err)
+	})
+}
+
+func TestValidate_ChecksDuplicateTitlesOnTheAPIServer(t *testing.T) {
+	existing := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tel-aviv", Name: "existing"},
+		Spec:       bookstoreexamplecomv1.BookSpec{Title: "Dune", Price: "10.00", Genre: "Fiction"},
+	}
+	v := BookCustomValidator{
+		Client:                   newFakeClientBuilder(testScheme()).Build(),
+		APIReader:                newFakeClientBuilder(testScheme()).WithObjects(existing).Build(),
+		AllowUnmanagedNamespaces: true,
+	}
+	obj := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tel-aviv", Name: "new"},
+		Spec:       bookstoreexamplecomv1.BookSpec{Title: "Dune", Price: "10.00", Genre: "Fiction"},
 	}
-	if msg := err.Error(); msg != "a Book without copyOf must have spec.title, spec.price, and spec.genre set (non-zero)" {
-		t.Errorf("unexpected error: %s", msg)
+
+	warnings, err := v.ValidateCreate(context.Background(), obj)
+	if err != nil {
+		t.Fatalf("expected no error: %v", err)
+	}
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], "spec.title: ") {
+		t.Errorf("expected the duplicate title found on the API server, got %v", warnings)
+	}
+}
+
+func TestValidate_AuthorizesCopiesForTheRequestingUser(t *testing.T) {
+	original := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tel-aviv", Name: "original"},
+		Spec:       bookstoreexamplecomv1.BookSpec{Title: "Orig", Price: "1", Genre: "Fiction"},
 	}
+	var reviews []authorizationv1.SubjectAccessReviewSpec
+	c := newFakeClientBuilder(testScheme()).WithObjects(original).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+			review := obj.(*authorizationv1.SubjectAccessReview)
+			reviews = append(reviews, review.Spec)
+			review.Status.Allowed = review.Spec.User == "reader"
+			return nil
+		},
+	}).Build()
+	v := BookCustomValidator{Client: c, AllowUnmanagedNamespaces: true}
+
+	asUser := func(user string) context.Context {
+		return admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{Username: user}},
+		})
+	}
+	copyOfOriginal := newCopy("hadera", bookstoreexamplecomv1.CopyOf{Namespace: "tel-aviv", Name: "original"})
+	copyOfMissing := newCopy("hadera", bookstoreexamplecomv1.CopyOf{Namespace: "tel-aviv", Name: "missing"})
+
+	t.Run("allows a user who can get the original", func(t *testing.T) {
+		reviews = nil
+		if _, err := v.ValidateCreate(asUser("reader"), copyOfOriginal); err != nil {
+			t.Fatalf("expected no error: %v", err)
+		}
+		want := authorizationv1.ResourceAttributes{
+			Namespace: "tel-aviv", Verb: "get", Group: "bookstore.example.com", Resource: "books", Name: "original",
+		}
+		if len(reviews) != 1 || reviews[0].User != "reader" || *reviews[0].ResourceAttributes != want {
+			t.Errorf("unexpected access reviews: %+v", reviews)
+		}
+	})
+
+	t.Run("denies anyone else without revealing whether the original exists", func(t *testing.T) {
+		_, err := v.ValidateCreate(asUser("stranger"), copyOfMissing)
+		expectFieldErrors(t, err, "spec.copyOf.namespace FieldValueForbidden")
+	})
+
+	t.Run("only checks again when copyOf changes", func(t *testing.T) {
+		reviews = nil
+		oldObj := copyOfOriginal.DeepCopy()
+		newObj := copyOfOriginal.DeepCopy()
+		newObj.Spec.Price = "3"
+		if _, err := v.ValidateUpdate(asUser("stranger"), oldObj, newObj); err != nil {
+			t.Fatalf("expected no error: %v", err)
+		}
+		if len(reviews) != 0 {
+			t.Errorf("expected no access review, got %+v", reviews)
+		}
+
+		newObj.Spec.CopyOf.Name = "missing"
+		_, err := v.ValidateUpdate(asUser("stranger"), oldObj, newObj)
+		expectFieldErrors(t, err, "spec.copyOf.namespace FieldValueForbidden")
+	})
+}
+
+func TestValidate_EnforcesTheSourceCopyPolicy(t *testing.T) {
+	source := &bookstoreexamplecomv1.BookStore{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tel-aviv"},
+		Spec: bookstoreexamplecomv1.BookStoreSpec{CopyPolicy: &bookstoreexamplecomv1.CopyPolicy{
+			Type:          bookstoreexamplecomv1.CopyPolicySelector,
+			StoreSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "flagship"}},
+		}},
+	}
+	flagship := &bookstoreexamplecomv1.BookStore{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default", Name: "hadera", Labels: map[string]string{"tier": "flagship"},
+	}}
+	outlet := &bookstoreexamplecomv1.BookStore{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "eilat"}}
+	original := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tel-aviv", Name: "original"},
+		Spec:       bookstoreexamplecomv1.BookSpec{Title: "Orig", Price: "1", Genre: "Fiction"},
+	}
+	c := newFakeClientBuilder(testScheme()).WithObjects(source, flagship, outlet, original).Build()
+	v := BookCustomValidator{Client: c}
+	copyOfOriginal := bookstoreexamplecomv1.CopyOf{Namespace: "tel-aviv", Name: "original"}
+
+	if _, err := v.ValidateCreate(context.Background(), newCopy("hadera", copyOfOriginal)); err != nil {
+		t.Fatalf("expected the flagship store to be allowed: %v", err)
+	}
+
+	_, err := v.ValidateCreate(context.Background(), newCopy("eilat", copyOfOriginal))
+	expectFieldErrors(t, err, "spec.copyOf FieldValueForbidden")
+	if !strings.Contains(err.Error(), `the copy policy of BookStore "tel-aviv" does not allow copies in namespace "eilat"`) {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	repointed := newCopy("eilat", copyOfOriginal)
+	repointed.Spec.CopyOf.Name = "other"
+	_, err = v.ValidateUpdate(context.Background(), newCopy("eilat", copyOfOriginal), repointed)
+	expectFieldErrors(t, err, "spec.copyOf FieldValueForbidden")
+}
+
+func TestValidateUpdate_TransitionRules(t *testing.T) {
+	original := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "original"},
+		Spec:       bookstoreexamplecomv1.BookSpec{Title: "Orig", Price: "1", Genre: "Fiction"},
+	}
+	other := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other"},
+		Spec:       bookstoreexamplecomv1.BookSpec{Title: "Other", Price: "1", Genre: "Fiction"},
+	}
+	c := newFakeClientBuilder(testScheme()).WithObjects(original, other).Build()
+	v := BookCustomValidator{Client: c, AllowUnmanagedNamespaces: true}
+
+	t.Run("a copied original cannot become a copy", func(t *testing.T) {
+		oldObj := original.DeepCopy()
+		oldObj.Status.ReferenceCount = 2
+		newObj := oldObj.DeepCopy()
+		newObj.Spec.CopyOf = &bookstoreexamplecomv1.CopyOf{Namespace: "default", Name: "other"}
+
+		_, err := v.ValidateUpdate(context.Background(), oldObj, newObj)
+		expectFieldErrors(t, err, "spec.copyOf FieldValueForbidden")
+		if !strings.Contains(err.Error(), "an original with 2 copies cannot become a copy") {
+			t.Errorf("unexpected error: %s", err)
+		}
+	})
+
+	t.Run("an original nobody copies can become a copy", func(t *testing.T) {
+		newObj := original.DeepCopy()
+		newObj.Spec.CopyOf = &bookstoreexamplecomv1.CopyOf{Namespace: "default", Name: "other"}
+
+		if _, err := v.ValidateUpdate(context.Background(), original, newObj); err != nil {
+			t.Fatalf("expected no error: %v", err)
+		}
+	})
+
+	t.Run("copyOf can be made immutable", func(t *testing.T) {
+		v := BookCustomValidator{
+			Client: c, AllowUnmanagedNamespaces: true,
+			Config: watcherWithConfig(t, "  book:\n    immutableCopyOf: true\n"),
+		}
+
+		oldObj := newCopy("default", bookstoreexamplecomv1.CopyOf{Namespace: "default", Name: "original"})
+		newObj := oldObj.DeepCopy()
+		newObj.Spec.CopyOf.Name = "other"
+		_, err := v.ValidateUpdate(context.Background(), oldObj, newObj)
+		expectFieldErrors(t, err, "spec.copyOf FieldValueInvalid")
+		if !strings.Contains(err.Error(), "field is immutable") {
+			t.Errorf("unexpected error: %s", err)
+		}
+
+		newObj = oldObj.DeepCopy()
+		newObj.Spec.Price = "3"
+		if _, err := v.ValidateUpdate(context.Background(), oldObj, newObj); err != nil {
+			t.Fatalf("expected other fields to stay editable: %v", err)
+		}
+	})
 }
@@ -0,0 +1,232 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+	"github.com/danieldanieltata/bookstore-operator/internal/decisions"
+	"github.com/danieldanieltata/bookstore-operator/internal/dryrun"
+	"github.com/danieldanieltata/bookstore-operator/internal/stores"
+	"github.com/danieldanieltata/bookstore-operator/internal/tracing"
+)
+
+// log is for logging in this package.
+var bookstorelog = logf.Log.WithName("bookstore-resource")
+
+// BookStoreWebhookOptions configures the BookStore webhook.
+type BookStoreWebhookOptions struct {
+	// DryRun admits requests the validator would deny and returns the denial as a warning.
+	DryRun bool
+	// DenialLog receives every denied request as a JSON line. Nil turns it off.
+	DenialLog *slog.Logger
+}
+
+// SetupBookStoreWebhookWithManager registers the webhook for BookStore in the manager.
+func SetupBookStoreWebhookWithManager(mgr ctrl.Manager, opts BookStoreWebhookOptions) error {
+	var validator admission.Validator[*bookstoreexamplecomv1.BookStore] = &BookStoreCustomValidator{
+		Client:    mgr.GetClient(),
+		APIReader: mgr.GetAPIReader(),
+	}
+	if opts.DryRun {
+		validator = dryrun.Validator(validator)
+	}
+	validator = decisions.Validator("BookStoreCustomValidator", opts.DenialLog, validator)
+	return ctrl.NewWebhookManagedBy(mgr, &bookstoreexamplecomv1.BookStore{}).
+		WithValidator(tracing.Validator("BookStoreCustomValidator", validator)).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-bookstore-example-com-v1-bookstore,mutating=false,failurePolicy=fail,sideEffects=None,groups=bookstore.example.com,resources=bookstores,verbs=create;update;delete,versions=v1,name=vbookstore-v1.kb.io,admissionReviewVersions=v1
+
+// BookStoreCustomValidator validates BookStores when they are created, updated, or deleted. A store
+// owns the namespace of the same name, so the name must be usable as one and must not be taken.
+type BookStoreCustomValidator struct {
+	Client client.Client
+
+	// APIReader looks up the store's namespace on the API server, since the cache only holds the
+	// namespaces the operator watches. Defaults to Client.
+	APIReader client.Reader
+}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type BookStore.
+func (v *BookStoreCustomValidator) ValidateCreate(ctx context.Context, obj *bookstoreexamplecomv1.BookStore) (admission.Warnings, error) {
+	bookstorelog.Info("Validation for BookStore upon creation", "name", obj.GetName())
+
+	allErrs := v.validateName(ctx, obj)
+	allErrs = append(allErrs, validateCopyPolicySpec(obj.Spec.CopyPolicy)...)
+	return nil, invalidBookStore(obj, allErrs)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type BookStore.
+func (v *BookStoreCustomValidator) ValidateUpdate(_ context.Context, _, newObj *bookstoreexamplecomv1.BookStore) (admission.Warnings, error) {
+	bookstorelog.Info("Validation for BookStore upon update", "name", newObj.GetName())
+
+	return nil, invalidBookStore(newObj, validateCopyPolicySpec(newObj.Spec.CopyPolicy))
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type BookStore.
+// Deleting a store whose Books are copied elsewhere leaves those copies without an original, so it
+// is refused unless the store carries ForceDeleteAnnotation, or its owning namespace is already gone
+// or terminating, in which case it is admitted with a warning. The latter is the garbage collector
+// cascading a namespace delete, which never sets the annotation and would otherwise retry forever.
+func (v *BookStoreCustomValidator) ValidateDelete(ctx context.Context, obj *bookstoreexamplecomv1.BookStore) (admission.Warnings, error) {
+	bookstorelog.Info("Validation for BookStore upon deletion", "name", obj.GetName())
+
+	holders, err := stores.CopyHolders(ctx, v.Client, obj)
+	if err != nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("failed to look up the stores holding copies of %q: %w", obj.Name, err))
+	}
+	if len(holders) == 0 {
+		return nil, nil
+	}
+	ownerGone, err := v.ownerNamespaceGone(ctx, obj)
+	if err != nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("failed to look up the namespace owning %q: %w", obj.Name, err))
+	}
+	if ownerGone || obj.GetAnnotations()[bookstoreexamplecomv1.ForceDeleteAnnotation] == "true" {
+		return admission.Warnings{fmt.Sprintf("BookStores %s hold copies of this store's Books, they will lose their original",
+			strings.Join(holders, ", "))}, nil
+	}
+	return nil, apierrors.NewForbidden(bookstoreexamplecomv1.GroupVersion.WithResource("bookstores").GroupResource(), obj.Name,
+		fmt.Errorf("BookStores %s hold copies of this store's Books; delete the copies first, or set the %s annotation to \"true\"",
+			strings.Join(holders, ", "), bookstoreexamplecomv1.ForceDeleteAnnotation))
+}
+
+// ownerNamespaceGone reports whether the Namespace owning obj has been deleted, replaced by a new one
+// of the same name, or is terminating.
+func (v *BookStoreCustomValidator) ownerNamespaceGone(ctx context.Context, obj *bookstoreexamplecomv1.BookStore) (bool, error) {
+	for _, owner := range obj.GetOwnerReferences() {
+		if owner.APIVersion != "v1" || owner.Kind != "Namespace" {
+			continue
+		}
+		var namespace corev1.Namespace
+		err := v.reader().Get(ctx, types.NamespacedName{Name: owner.Name}, &namespace)
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if namespace.UID != owner.UID || namespace.DeletionTimestamp != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// reader returns APIReader, or Client when it is not set.
+func (v *BookStoreCustomValidator) reader() client.Reader {
+	if v.APIReader != nil {
+		return v.APIReader
+	}
+	return v.Client
+}
+
+// invalidBookStore wraps allErrs in the Invalid status error the API server returns to the client, or
+// returns nil when there is nothing to report.
+func invalidBookStore(obj *bookstoreexamplecomv1.BookStore, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(bookstoreexamplecomv1.GroupVersion.WithKind("BookStore").GroupKind(), obj.GetName(), allErrs)
+}
+
+// validateName checks that a new store's name can become its namespace: it must be a valid namespace
+// name, an existing namespace of that name must already be labelled for the store, and no other
+// BookStore may claim it.
+func (v *BookStoreCustomValidator) validateName(ctx context.Context, obj *bookstoreexamplecomv1.BookStore) field.ErrorList {
+	namePath := field.NewPath("metadata", "name")
+	var allErrs field.ErrorList
+	for _, msg := range validation.IsDNS1123Label(obj.Name) {
+		allErrs = append(allErrs, field.Invalid(namePath, obj.Name, "must be a valid namespace name: "+msg))
+	}
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	var namespace corev1.Namespace
+	err := v.reader().Get(ctx, types.NamespacedName{Name: obj.Name}, &namespace)
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return field.ErrorList{field.InternalError(namePath, fmt.Errorf("failed to look up namespace %q: %w", obj.Name, err))}
+	case namespace.Labels[bookstoreexamplecomv1.BookStoreLabel] != obj.Name:
+		allErrs = append(allErrs, field.Forbidden(namePath, fmt.Sprintf(
+			"namespace %q already exists and is not adopted; label it %s=%s to let the store adopt it",
+			obj.Name, bookstoreexamplecomv1.BookStoreLabel, obj.Name)))
+	}
+
+	var bookstores bookstoreexamplecomv1.BookStoreList
+	if err := v.Client.List(ctx, &bookstores); err != nil {
+		return append(allErrs, field.InternalError(namePath, fmt.Errorf("failed to list BookStores: %w", err)))
+	}
+	for _, other := range bookstores.Items {
+		if other.Name == obj.Name && other.Namespace != obj.Namespace {
+			allErrs = append(allErrs, field.Duplicate(namePath, obj.Name))
+			break
+		}
+	}
+	return allErrs
+}
+
+// validateCopyPolicySpec checks that storeSelector is set exactly when the policy type is Selector, and
+// that it parses.
+func validateCopyPolicySpec(policy *bookstoreexamplecomv1.CopyPolicy) field.ErrorList {
+	if policy == nil {
+		return nil
+	}
+	policyPath := field.NewPath("spec", "copyPolicy")
+	selectorPath := policyPath.Child("storeSelector")
+	var allErrs field.ErrorList
+	switch policy.Type {
+	case "", bookstoreexamplecomv1.CopyPolicyAllowAll, bookstoreexamplecomv1.CopyPolicyDenyAll:
+		if policy.StoreSelector != nil {
+			allErrs = append(allErrs, field.Forbidden(selectorPath,
+				fmt.Sprintf("only allowed when type is %s", bookstoreexamplecomv1.CopyPolicySelector)))
+		}
+	case bookstoreexamplecomv1.CopyPolicySelector:
+		if policy.StoreSelector == nil {
+			allErrs = append(allErrs, field.Required(selectorPath,
+				fmt.Sprintf("required when type is %s", bookstoreexamplecomv1.CopyPolicySelector)))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(policyPath.Child("type"), policy.Type, []bookstoreexamplecomv1.CopyPolicyType{
+			bookstoreexamplecomv1.CopyPolicyAllowAll, bookstoreexamplecomv1.CopyPolicyDenyAll, bookstoreexamplecomv1.CopyPolicySelector,
+		}))
+	}
+	if policy.StoreSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(policy.StoreSelector,
+			metav1validation.LabelSelectorValidationOptions{}, selectorPath)...)
+	}
+	return allErrs
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+)
+
+func newBookStoreValidator(objs ...client.Object) *BookStoreCustomValidator {
+	s := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(s)
+	_ = bookstoreexamplecomv1.AddToScheme(s)
+	return &BookStoreCustomValidator{Client: newFakeClientBuilder(s).WithObjects(objs...).Build()}
+}
+
+func TestBookStoreValidateCreate_ChecksTheName(t *testing.T) {
+	adopted := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name: "adopted", Labels: map[string]string{bookstoreexamplecomv1.BookStoreLabel: "adopted"},
+	}}
+	taken := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "taken"}}
+	claimed := &bookstoreexamplecomv1.BookStore{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "claimed"}}
+
+	cases := map[string]struct {
+		name string
+		want []string
+	}{
+		"new namespace":       {name: "fresh"},
+		"adopted namespace":   {name: "adopted"},
+		"invalid name":        {name: "Not_A_Namespace", want: []string{"metadata.name FieldValueInvalid"}},
+		"unadopted namespace": {name: "taken", want: []string{"metadata.name FieldValueForbidden"}},
+		"claimed by a store":  {name: "claimed", want: []string{"metadata.name FieldValueDuplicate"}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := newBookStoreValidator(adopted, taken, claimed)
+			obj := &bookstoreexamplecomv1.BookStore{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: tc.name}}
+
+			_, err := v.ValidateCreate(context.Background(), obj)
+			if len(tc.want) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error: %v", err)
+				}
+				return
+			}
+			expectFieldErrors(t, err, tc.want...)
+		})
+	}
+}
+
+func TestBookStoreValidateUpdate_ChecksTheCopyPolicy(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}}
+	cases := map[string]struct {
+		policy *bookstoreexamplecomv1.CopyPolicy
+		want   []string
+	}{
+		"no policy": {},
+		"selector":  {policy: &bookstoreexamplecomv1.CopyPolicy{Type: bookstoreexamplecomv1.CopyPolicySelector, StoreSelector: selector}},
+		"selector without storeSelector": {
+			policy: &bookstoreexamplecomv1.CopyPolicy{Type: bookstoreexamplecomv1.CopyPolicySelector},
+			want:   []string{"spec.copyPolicy.storeSelector FieldValueRequired"},
+		},
+		"storeSelector with DenyAll": {
+			policy: &bookstoreexamplecomv1.CopyPolicy{Type: bookstoreexamplecomv1.CopyPolicyDenyAll, StoreSelector: selector},
+			want:   []string{"spec.copyPolicy.storeSelector FieldValueForbidden"},
+		},
+		"malformed storeSelector": {
+			policy: &bookstoreexamplecomv1.CopyPolicy{Type: bookstoreexamplecomv1.CopyPolicySelector, StoreSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: metav1.LabelSelectorOpIn}},
+			}},
+			want: []string{"spec.copyPolicy.storeSelector.matchExpressions[0].values FieldValueRequired"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := newBookStoreValidator()
+			obj := &bookstoreexamplecomv1.BookStore{ObjectMeta: metav1.ObjectMeta{Name: "store"}}
+			obj.Spec.CopyPolicy = tc.policy
+
+			_, err := v.ValidateUpdate(context.Background(), obj.DeepCopy(), obj)
+			if len(tc.want) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error: %v", err)
+				}
+				return
+			}
+			expectFieldErrors(t, err, tc.want...)
+		})
+	}
+}
+
+func TestBookStoreValidateDelete_GuardsStoresWithCopies(t *testing.T) {
+	source := &bookstoreexamplecomv1.BookStore{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "source"}}
+	holder := &bookstoreexamplecomv1.BookStore{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "holder"}}
+	copyBook := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "holder", Name: "copy"},
+		Spec: bookstoreexamplecomv1.BookSpec{
+			Title:  "Copy",
+			CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: "source", Name: "original"},
+		},
+	}
+
+	v := newBookStoreValidator(source, holder)
+	if warnings, err := v.ValidateDelete(context.Background(), source); err != nil || len(warnings) > 0 {
+		t.Fatalf("expected a store without copies to be deleted quietly, got %v, %v", warnings, err)
+	}
+
+	v = newBookStoreValidator(source, holder, copyBook)
+	_, err := v.ValidateDelete(context.Background(), source)
+	if !apierrors.IsForbidden(err) || !strings.Contains(err.Error(), "holder") {
+		t.Fatalf("expected a Forbidden error naming the holder, got %v", err)
+	}
+
+	forced := source.DeepCopy()
+	forced.Annotations = map[string]string{bookstoreexamplecomv1.ForceDeleteAnnotation: "true"}
+	warnings, err := v.ValidateDelete(context.Background(), forced)
+	if err != nil {
+		t.Fatalf("expected the forced delete to be admitted: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "holder") {
+		t.Errorf("expected a warning naming the holder, got %v", warnings)
+	}
+}
+
+func TestBookStoreValidateDelete_AdmitsTheNamespaceCascade(t *testing.T) {
+	holder := &bookstoreexamplecomv1.BookStore{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "holder"}}
+	copyBook := &bookstoreexamplecomv1.Book{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "holder", Name: "copy"},
+		Spec: bookstoreexamplecomv1.BookSpec{
+			Title:  "Copy",
+			CopyOf: &bookstoreexamplecomv1.CopyOf{Namespace: "source", Name: "original"},
+		},
+	}
+	source := &bookstoreexamplecomv1.BookStore{ObjectMeta: metav1.ObjectMeta{
+		Namespace:       "default",
+		Name:            "source",
+		OwnerReferences: []metav1.OwnerReference{{APIVersion: "v1", Kind: "Namespace", Name: "source", UID: "source-uid"}},
+	}}
+	now := metav1.Now()
+
+	cases := map[string]struct {
+		namespace *corev1.Namespace
+		admitted  bool
+	}{
+		"owner namespace deleted": {admitted: true},
+		"owner namespace terminating": {
+			namespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name: "source", UID: "source-uid", DeletionTimestamp: &now, Finalizers: []string{"kubernetes"},
+			}},
+			admitted: true,
+		},
+		"owner namespace recreated": {
+			namespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "source", UID: "new-uid"}},
+			admitted:  true,
+		},
+		"owner namespace live": {
+			namespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "source", UID: "source-uid"}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			objs := []client.Object{source, holder, copyBook}
+			if tc.namespace != nil {
+				objs = append(objs, tc.namespace)
+			}
+			v := newBookStoreValidator(objs...)
+
+			warnings, err := v.ValidateDelete(context.Background(), source)
+			if !tc.admitted {
+				if !apierrors.IsForbidden(err) {
+					t.Fatalf("expected a Forbidden error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected the cascading delete to be admitted: %v", err)
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0], "holder") {
+				t.Errorf("expected a warning naming the holder, got %v", warnings)
+			}
+		})
+	}
+}
@@ -40,6 +40,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	bookstoreexamplecomv1 "github.com/danieldanieltata/bookstore-operator/api/v1"
+	"github.com/danieldanieltata/bookstore-operator/internal/stores"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -109,7 +110,13 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
-	err = SetupBookWebhookWithManager(mgr)
+	err = stores.IndexFields(ctx, mgr.GetFieldIndexer())
+	Expect(err).NotTo(HaveOccurred())
+
+	err = SetupBookWebhookWithManager(mgr, BookWebhookOptions{})
+	Expect(err).NotTo(HaveOccurred())
+
+	err = SetupBookStoreWebhookWithManager(mgr, BookStoreWebhookOptions{})
 	Expect(err).NotTo(HaveOccurred())
 
 	// +kubebuilder:scaffold:webhook